@@ -0,0 +1,62 @@
+package units
+
+// Concentration returns the concentration of an absorbing species from its
+// absorbance by the Beer–Lambert law, c = A/(ε·l), converted to unitString.
+//
+// The absorbance must be in AU or OD or be a plain number; other
+// dimensionless quantities, like percentages or stock multiples (X), are
+// rejected. The extinction coefficient may be molar (e.g., M^-1 cm^-1) or by
+// mass (e.g., ml mg^-1 cm^-1), provided unitString is a concentration of the
+// same kind.
+func Concentration(unitString string, absorbance, epsilon, pathLength Measurement) (Measurement, error) {
+	a, err := parse(absorbance)
+	if err != nil {
+		return zeroValue, err
+	}
+	if len(a.unit.Terms) != 0 {
+		au, err := New("AU", a)
+		if err != nil {
+			return zeroValue, err
+		}
+		// Absorbance is combined with the other terms as a plain number
+		a = &measure{
+			Value: au.Quantity(),
+			unit:  &pUnit{},
+		}
+	}
+	invEpsilon, err := Reciprocal(epsilon)
+	if err != nil {
+		return zeroValue, err
+	}
+	invPathLength, err := Reciprocal(pathLength)
+	if err != nil {
+		return zeroValue, err
+	}
+	return New(unitString, a, invEpsilon, invPathLength)
+}
+
+// Mass concentration of nucleic acids giving an absorbance of 1 at 260 nm over
+// a 1 cm path, in μg/ml
+const (
+	dsDNAA260Concentration = 50.0
+	ssDNAA260Concentration = 33.0
+	rnaA260Concentration   = 40.0
+)
+
+// A260Concentration returns the mass concentration in μg/ml of the nucleic
+// acid from its absorbance at 260 nm, using the standard factors of 50 μg/ml
+// for double stranded DNA, 33 μg/ml for single stranded DNA and 40 μg/ml for
+// RNA per absorbance unit over a 1 cm path.
+func (a NucleicAcid) A260Concentration(absorbance, pathLength Measurement) (Measurement, error) {
+	factor := ssDNAA260Concentration
+	if a.Type == RNA {
+		factor = rnaA260Concentration
+	} else if a.DoubleStranded {
+		factor = dsDNAA260Concentration
+	}
+	epsilon, err := Parse(1.0/factor, "ml/(μg cm)")
+	if err != nil {
+		return zeroValue, err
+	}
+	return Concentration("μg/ml", absorbance, epsilon, pathLength)
+}
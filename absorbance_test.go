@@ -0,0 +1,144 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConcentration(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		Absorbance Measurement
+		Epsilon    Measurement
+		PathLength Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			// NADH at 340 nm
+			Unit:       "μM",
+			Absorbance: Must(Parse(0.622, "AU")),
+			Epsilon:    Must(Parse(6220.0, "M^-1 cm^-1")),
+			PathLength: Must(Parse(1.0, "cm")),
+			Expected:   100.0,
+		},
+		testCase{
+			Unit:       "mmol/L",
+			Absorbance: Must(Parse(0.5, "OD")),
+			Epsilon:    Must(Parse(1000.0, "L mol^-1 cm^-1")),
+			PathLength: Must(Parse(5.0, "mm")),
+			Expected:   1.0,
+		},
+		testCase{
+			Unit:       "mg/ml",
+			Absorbance: Must(Parse(1.4, "")),
+			Epsilon:    Must(Parse(1.4, "ml mg^-1 cm^-1")),
+			PathLength: Must(Parse(1.0, "cm")),
+			Expected:   1.0,
+		},
+		testCase{
+			Unit:       "mg/ml",
+			Absorbance: Must(Parse(1.0, "AU")),
+			Epsilon:    Must(Parse(6220.0, "M^-1 cm^-1")),
+			PathLength: Must(Parse(1.0, "cm")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "μM",
+			Absorbance: Must(Parse(62.2, "%")),
+			Epsilon:    Must(Parse(6220.0, "M^-1 cm^-1")),
+			PathLength: Must(Parse(1.0, "cm")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "M",
+			Absorbance: Must(Parse(1.0, "mol")),
+			Epsilon:    Must(Parse(6220.0, "M^-1 cm^-1")),
+			PathLength: Must(Parse(1.0, "cm")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "M",
+			Absorbance: Must(Parse(1.0, "AU")),
+			Epsilon:    Must(Parse(6220.0, "M^-1 cm^-1")),
+			PathLength: Must(Parse(0.0, "cm")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := Concentration(tc.Unit, tc.Absorbance, tc.Epsilon, tc.PathLength)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+		} else if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestAbsorbanceUnits(t *testing.T) {
+	if m, err := New("OD", Must(Parse(0.5, "AU"))); err != nil {
+		t.Error(err)
+	} else if e, f := 0.5, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := New("", Must(Parse(2.0, "AU")), Must(Parse(0.5, "AU^-1"))); err != nil {
+		t.Error(err)
+	} else if e, f := 1.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	for _, tc := range [][2]string{
+		{"AU", "%"},
+		{"%", "AU"},
+		{"AU", ""},
+		{"", "OD"},
+		{"OD", "ppm"},
+	} {
+		if m, err := New(tc[0], Must(Parse(10.0, tc[1]))); err == nil {
+			t.Errorf("%q to %q: expecting error got %v", tc[1], tc[0], m)
+		}
+	}
+}
+
+func TestA260Concentration(t *testing.T) {
+	type testCase struct {
+		Acid     NucleicAcid
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Acid:     NucleicAcid{DoubleStranded: true},
+			Expected: 25.0,
+		},
+		testCase{
+			Acid:     NucleicAcid{},
+			Expected: 16.5,
+		},
+		testCase{
+			Acid:     NucleicAcid{Type: RNA},
+			Expected: 20.0,
+		},
+	}
+
+	// A260 of 0.05 over a 1 mm path
+	a := Must(Parse(0.05, "AU"))
+	l := Must(Parse(1.0, "mm"))
+	for _, tc := range suite {
+		m, err := tc.Acid.A260Concentration(a, l)
+		if err != nil {
+			t.Error(err)
+		} else if e, f := "μg/ml", m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package units
+
+import (
+	"math"
+)
+
+// Sin returns the sine of an angle or dimensionless measurement in radians.
+func Sin(m Measurement) (float64, error) {
+	x, err := New("rad", m)
+	if err != nil {
+		return 0.0, err
+	}
+	return math.Sin(x.Quantity()), nil
+}
+
+// Cos returns the cosine of an angle or dimensionless measurement in radians.
+func Cos(m Measurement) (float64, error) {
+	x, err := New("rad", m)
+	if err != nil {
+		return 0.0, err
+	}
+	return math.Cos(x.Quantity()), nil
+}
+
+// Atan2 returns the angle in rad of the point (x, y). The measurements may
+// have any dimension and scale, but they must have the same dimension.
+func Atan2(y, x Measurement) (Measurement, error) {
+	yy, err := parse(y)
+	if err != nil {
+		return zeroValue, err
+	}
+	if yy.unit.Nonlinear != nil {
+		return zeroValue, errNonlinear
+	}
+	xx, err := defaultRegistry.convert(yy.unit, yy.Unit, x)
+	if err != nil {
+		return zeroValue, err
+	}
+	return Parse(math.Atan2(yy.Value, xx.Quantity()), "rad")
+}
+
+// Exp returns e raised to a dimensionless measurement.
+func Exp(m Measurement) (float64, error) {
+	x, err := New("", m)
+	if err != nil {
+		return 0.0, err
+	}
+	return math.Exp(x.Quantity()), nil
+}
+
+// Log returns the natural logarithm of a dimensionless measurement.
+func Log(m Measurement) (float64, error) {
+	x, err := New("", m)
+	if err != nil {
+		return 0.0, err
+	}
+	if !(x.Quantity() > 0.0) {
+		return 0.0, errNonPositive
+	}
+	return math.Log(x.Quantity()), nil
+}
@@ -0,0 +1,184 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAngle(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		From     Measurement
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "rad",
+			From:     Must(Parse(180.0, "deg")),
+			Expected: math.Pi,
+		},
+		testCase{
+			Unit:     "°",
+			From:     Must(Parse(1.0, "rev")),
+			Expected: 360.0,
+		},
+		testCase{
+			Unit:     "arcmin",
+			From:     Must(Parse(1.0, "°")),
+			Expected: 60.0,
+		},
+		testCase{
+			Unit:     "″",
+			From:     Must(Parse(1.0, "′")),
+			Expected: 60.0,
+		},
+		testCase{
+			Unit:     "gon",
+			From:     Must(Parse(90.0, "deg")),
+			Expected: 100.0,
+		},
+		testCase{
+			Unit:     "mdeg",
+			From:     Must(Parse(1.0, "arcsec")),
+			Expected: 1.0 / 3.6,
+		},
+		testCase{
+			Unit:     "°/s",
+			From:     Must(Parse(math.Pi, "rad/s")),
+			Expected: 180.0,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if err != nil {
+			t.Errorf("%v %s to %q: %s", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*math.Abs(e) {
+			t.Errorf("%v %s to %q: expecting %v found %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+
+	// Angles are not interchangeable with other dimensionless ratios
+	if m, err := New("deg", Must(Parse(1.0, "g/g"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	// Angular velocities are not frequencies
+	for _, tc := range [][2]string{
+		{"rev/min", "rpm"},
+		{"rpm", "rev/min"},
+		{"Hz", "rad/s"},
+		{"deg/s", "kHz"},
+	} {
+		if m, err := New(tc[0], Must(Parse(60.0, tc[1]))); err == nil {
+			t.Errorf("%q to %q: expecting error got %v", tc[1], tc[0], m)
+		}
+		if c, err := NewConverter(tc[1], tc[0]); err == nil {
+			t.Errorf("%q to %q: expecting error got %v", tc[1], tc[0], c)
+		}
+	}
+	if m, err := New("rad/s", Must(Parse(60.0, "rev/min"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2*math.Pi, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	// Kindless rates convert to and from both
+	for _, tc := range []struct {
+		Unit     string
+		From     Measurement
+		Expected float64
+	}{
+		{Unit: "s^-1", From: Must(Parse(1.0, "mm/(m·s)")), Expected: 1e-3},
+		{Unit: "s^-1", From: Must(Parse(2.0, "rad/s")), Expected: 2.0},
+		{Unit: "rad/s", From: Must(Parse(2.0, "s^-1")), Expected: 2.0},
+		{Unit: "Hz", From: Must(Parse(2.0, "s^-1")), Expected: 2.0},
+	} {
+		if m, err := New(tc.Unit, tc.From); err != nil {
+			t.Errorf("%q to %q: %s", tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("%q to %q: expecting %v found %v", tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+}
+
+func TestMath(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Func     func(Measurement) (float64, error)
+		Arg      Measurement
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Name:     "Sin",
+			Func:     Sin,
+			Arg:      Must(Parse(30.0, "deg")),
+			Expected: 0.5,
+		},
+		testCase{
+			Name:     "Sin",
+			Func:     Sin,
+			Arg:      Must(Parse(math.Pi/2, "")),
+			Expected: 1.0,
+		},
+		testCase{
+			Name:     "Cos",
+			Func:     Cos,
+			Arg:      Must(Parse(0.5, "rev")),
+			Expected: -1.0,
+		},
+		testCase{
+			Name:     "Exp",
+			Func:     Exp,
+			Arg:      Must(Parse(100.0, "%")),
+			Expected: math.E,
+		},
+		testCase{
+			Name:     "Log",
+			Func:     Log,
+			Arg:      Must(Parse(1.0, "mm/m")),
+			Expected: math.Log(1e-3),
+		},
+	}
+
+	for _, tc := range suite {
+		v, err := tc.Func(tc.Arg)
+		if err != nil {
+			t.Errorf("%s(%v %s): %s", tc.Name, tc.Arg.Quantity(), tc.Arg.MeasurementUnit(), err)
+		} else if e, f := tc.Expected, v; math.Abs(e-f) > 1e-12*math.Max(1, math.Abs(e)) {
+			t.Errorf("%s(%v %s): expecting %v found %v", tc.Name, tc.Arg.Quantity(), tc.Arg.MeasurementUnit(), e, f)
+		}
+	}
+
+	for _, f := range []func(Measurement) (float64, error){Sin, Cos, Exp, Log} {
+		if v, err := f(Must(Parse(1.0, "m"))); err != errWrongDimension {
+			t.Errorf("expecting %v got %v, %v", errWrongDimension, v, err)
+		}
+	}
+	if v, err := Log(Must(Parse(0.0, ""))); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	m, err := Atan2(Must(Parse(1.0, "mm")), Must(Parse(-0.1, "cm")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.MeasurementUnit() != "rad" {
+		t.Errorf("expecting rad found %q", m.MeasurementUnit())
+	}
+	if e, f := 3*math.Pi/4, m.Quantity(); math.Abs(e-f) > 1e-12 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if m, err := Atan2(Must(Parse(1.0, "m")), Must(Parse(1.0, "s"))); err != errWrongDimension {
+		t.Errorf("expecting %v got %v, %v", errWrongDimension, m, err)
+	}
+	if m, err := Atan2(Must(Parse(1.0, "Bq")), Must(Parse(1.0, "Hz"))); err != errWrongKind {
+		t.Errorf("expecting %v got %v, %v", errWrongKind, m, err)
+	}
+}
@@ -0,0 +1,59 @@
+package units
+
+import (
+	"math"
+)
+
+// Standard acceleration of gravity in m/s^2
+const standardGravity = 9.80665
+
+// Return the angular velocity of a rotational speed in rad/s. Speeds in
+// angular units (e.g., rad/s) are used as is; other speeds (e.g., rpm or Hz)
+// are taken as revolutions per unit time.
+func angularVelocity(speed Measurement) (float64, error) {
+	m, err := parse(speed)
+	if err != nil {
+		return 0.0, err
+	}
+	if ratio, err := m.unit.ratio(); err != nil || ratio != (uPoint{}) {
+		w, err := New("rad/s", m)
+		if err != nil {
+			return 0.0, err
+		}
+		return w.Quantity(), nil
+	}
+	f, err := New("Hz", m)
+	if err != nil {
+		return 0.0, err
+	}
+	return 2.0 * math.Pi * f.Quantity(), nil
+}
+
+// RelativeCentrifugalForce returns the relative centrifugal force in ×g of a
+// rotor spinning at speed (e.g., in rpm) at the given radius.
+func RelativeCentrifugalForce(speed, radius Measurement) (Measurement, error) {
+	w, err := angularVelocity(speed)
+	if err != nil {
+		return zeroValue, err
+	}
+	omega := Must(Parse(w, "s^-1"))
+	return New("×g", radius, omega, omega)
+}
+
+// RotationalSpeed returns the speed in rpm at which a rotor gives the relative
+// centrifugal force rcf (e.g., in ×g) at the given radius.
+func RotationalSpeed(rcf, radius Measurement) (Measurement, error) {
+	invRadius, err := Reciprocal(radius)
+	if err != nil {
+		return zeroValue, err
+	}
+	w2, err := New("s^-2", rcf, invRadius)
+	if err != nil {
+		return zeroValue, err
+	}
+	if w2.Quantity() < 0.0 {
+		return zeroValue, errWrongDimension
+	}
+	f := math.Sqrt(w2.Quantity()) / (2.0 * math.Pi)
+	return New("rpm", Must(Parse(f, "Hz")))
+}
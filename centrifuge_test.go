@@ -0,0 +1,92 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRelativeCentrifugalForce(t *testing.T) {
+	type testCase struct {
+		Speed      Measurement
+		Radius     Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	// RCF = 1.118e-5 · r[cm] · rpm^2
+	suite := []testCase{
+		testCase{
+			Speed:    Must(Parse(13000.0, "rpm")),
+			Radius:   Must(Parse(8.4, "cm")),
+			Expected: 1.11824e-5 * 8.4 * 13000 * 13000,
+		},
+		testCase{
+			Speed:    Must(Parse(50.0, "Hz")),
+			Radius:   Must(Parse(100.0, "mm")),
+			Expected: 1.11824e-5 * 10 * 3000 * 3000,
+		},
+		testCase{
+			Speed:    Must(Parse(100.0*math.Pi, "rad/s")),
+			Radius:   Must(Parse(0.1, "m")),
+			Expected: 1.11824e-5 * 10 * 3000 * 3000,
+		},
+		testCase{
+			Speed:      Must(Parse(13000.0, "rpm")),
+			Radius:     Must(Parse(8.4, "g")),
+			ShouldFail: true,
+		},
+		testCase{
+			Speed:      Must(Parse(13000.0, "m")),
+			Radius:     Must(Parse(8.4, "cm")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := RelativeCentrifugalForce(tc.Speed, tc.Radius)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Error(err)
+		} else if e, f := "×g", m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-5*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+
+		// Round trip
+		speed, err := RotationalSpeed(m, tc.Radius)
+		if err != nil {
+			t.Error(err)
+		} else if rcf, err := RelativeCentrifugalForce(speed, tc.Radius); err != nil {
+			t.Error(err)
+		} else if e, f := m.Quantity(), rcf.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestRotationalSpeed(t *testing.T) {
+	m, err := RotationalSpeed(Must(Parse(1000.0, "xg")), Must(Parse(10.0, "cm")))
+	if err != nil {
+		t.Fatal(err)
+	} else if e, f := "rpm", m.MeasurementUnit(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	} else if e, f := math.Sqrt(1000/(1.11824e-5*10)), m.Quantity(); math.Abs(e-f) > 1e-5*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if m, err := RotationalSpeed(Must(Parse(1000.0, "xg")), Must(Parse(10.0, "s"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	if m, err := New("Hz", Must(Parse(600.0, "rpm"))); err != nil {
+		t.Error(err)
+	} else if e, f := 10.0, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
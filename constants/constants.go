@@ -0,0 +1,53 @@
+// Package constants provides physical constants as measurements
+package constants
+
+import (
+	"github.com/antha-lang/units"
+)
+
+// A Constant is a measurement with a standard uncertainty. Constants can be
+// used wherever a measurement is expected, e.g., units.New("J/mol",
+// constants.R, temperature).
+type Constant struct {
+	units.Measurement
+	// Standard uncertainty in the unit of the measurement; zero for exact
+	// constants
+	Uncertainty float64
+}
+
+// Constants of the 2019 SI and CODATA 2018. Since the 2019 redefinition of the
+// SI base units, all of these are exact.
+var (
+	// Avogadro constant
+	NA = mustExact(6.02214076e23, "mol^-1")
+	// Boltzmann constant
+	KB = mustExact(1.380649e-23, "J/K")
+	// Planck constant
+	H = mustExact(6.62607015e-34, "J·s")
+	// Speed of light in vacuum
+	C = mustExact(299792458, "m/s")
+	// Elementary charge
+	E = mustExact(1.602176634e-19, "C")
+	// Molar gas constant, NA·kB
+	R = mustExactProduct("J/(mol·K)", NA, KB)
+	// Faraday constant, NA·e
+	F = mustExactProduct("C/mol", NA, E)
+	// Standard acceleration of gravity
+	G0 = mustExact(9.80665, "m/s^2")
+)
+
+func mustExact(value float64, unit string) Constant {
+	return Constant{
+		Measurement: units.Must(units.Parse(value, unit)),
+	}
+}
+
+func mustExactProduct(unit string, cs ...Constant) Constant {
+	ms := make([]units.Measurement, 0, len(cs))
+	for _, c := range cs {
+		ms = append(ms, c.Measurement)
+	}
+	return Constant{
+		Measurement: units.Must(units.New(unit, ms[0], ms[1:]...)),
+	}
+}
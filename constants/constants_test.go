@@ -0,0 +1,97 @@
+package constants
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antha-lang/units"
+)
+
+func TestConstants(t *testing.T) {
+	type testCase struct {
+		Name     string
+		Constant Constant
+		Unit     string
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Name:     "NA",
+			Constant: NA,
+			Unit:     "mmol^-1",
+			Expected: 6.02214076e20,
+		},
+		testCase{
+			Name:     "kB",
+			Constant: KB,
+			Unit:     "kg·m^2/(s^2·K)",
+			Expected: 1.380649e-23,
+		},
+		testCase{
+			Name:     "h",
+			Constant: H,
+			Unit:     "J/Hz",
+			Expected: 6.62607015e-34,
+		},
+		testCase{
+			Name:     "c",
+			Constant: C,
+			Unit:     "km/s",
+			Expected: 299792.458,
+		},
+		testCase{
+			Name:     "e",
+			Constant: E,
+			Unit:     "A·s",
+			Expected: 1.602176634e-19,
+		},
+		testCase{
+			Name:     "R",
+			Constant: R,
+			Unit:     "J/(mol·K)",
+			Expected: 8.314462618153241,
+		},
+		testCase{
+			Name:     "F",
+			Constant: F,
+			Unit:     "C/mol",
+			Expected: 96485.33212331001,
+		},
+		testCase{
+			Name:     "g0",
+			Constant: G0,
+			Unit:     "×g",
+			Expected: 1.0,
+		},
+	}
+
+	for _, tc := range suite {
+		if tc.Constant.Uncertainty != 0 {
+			t.Errorf("%s: expecting exact value found uncertainty %v", tc.Name, tc.Constant.Uncertainty)
+		}
+		// Constants are usable as measurements directly or through their
+		// unit strings
+		for _, m := range []units.Measurement{tc.Constant, tc.Constant.Measurement} {
+			v, err := units.New(tc.Unit, m)
+			if err != nil {
+				t.Errorf("%s: %s", tc.Name, err)
+			} else if e, f := tc.Expected, v.Quantity(); math.Abs(e-f) > 1e-15*math.Abs(e) {
+				t.Errorf("%s: expecting %v found %v", tc.Name, e, f)
+			}
+		}
+	}
+}
+
+func TestConstantsDimensions(t *testing.T) {
+	// Thermal energy per mole at body temperature
+	if m, err := units.New("kJ/mol", R, units.Must(units.Parse(310.15, "K"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2.578730, m.Quantity(); math.Abs(e-f) > 1e-6 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if m, err := units.New("J", H); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+}
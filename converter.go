@@ -0,0 +1,116 @@
+package units
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	errLengthMismatch = errors.New("length mismatch")
+)
+
+// A Converter converts quantities from one unit to another. All parsing and
+// dimension checking is done when the Converter is created, so conversions
+// themselves do not allocate. A Converter is safe for concurrent use.
+type Converter struct {
+	from      string
+	to        string
+	scaleDiff int
+	pow10     float64 // 10^scaleDiff, possibly out of range
+	factor    float64 // Non-decimal part of conversion
+}
+
+// NewConverter returns a Converter from quantities in fromUnit to quantities
+// in toUnit. An error is returned if either unit fails to parse or if the
+// units have different dimensions, as with New. Nonlinear units are not
+// supported.
+func NewConverter(fromUnit, toUnit string) (*Converter, error) {
+	return defaultRegistry.NewConverter(fromUnit, toUnit)
+}
+
+// NewConverter is like the package-level NewConverter but also accepts the
+// optional units of the registry.
+func (r *Registry) NewConverter(fromUnit, toUnit string) (*Converter, error) {
+	from, err := r.lookup(fromUnit)
+	if err != nil {
+		return nil, err
+	}
+	to, err := r.lookup(toUnit)
+	if err != nil {
+		return nil, err
+	}
+
+	if from.Nonlinear != nil || to.Nonlinear != nil {
+		return nil, errNonlinear
+	}
+	if from.product() != to.product() {
+		return nil, errWrongDimension
+	}
+	fromRatio, err := from.ratio()
+	if err != nil {
+		return nil, err
+	}
+	toRatio, err := to.ratio()
+	if err != nil {
+		return nil, err
+	}
+	if !compatibleRatios(fromRatio, toRatio) {
+		return nil, errWrongRatio
+	}
+	if !compatibleKinds(from.Kinds, to.Kinds) {
+		return nil, errWrongKind
+	}
+
+	scaleDiff := from.Scale - to.Scale
+	return &Converter{
+		from:      fromUnit,
+		to:        toUnit,
+		scaleDiff: scaleDiff,
+		pow10:     math.Pow10(scaleDiff),
+		factor:    from.factor() / to.factor(),
+	}, nil
+}
+
+// FromUnit returns the unit of quantities accepted by the Converter
+func (a *Converter) FromUnit() string {
+	return a.from
+}
+
+// ToUnit returns the unit of quantities returned by the Converter
+func (a *Converter) ToUnit() string {
+	return a.to
+}
+
+// Convert converts a single quantity. As with New, an error is returned if
+// the conversion overflows or underflows.
+func (a *Converter) Convert(value float64) (float64, error) {
+	// Same order of operations as New so that results agree exactly
+	r := value * a.factor * a.pow10
+	if !inRange(r) && inRange(value) {
+		r = makeExtFloat(value).Multiply(makeExtFloat(a.factor)).MultiplyPow10(a.scaleDiff).Float64()
+	}
+	if r == 0.0 && value != 0.0 {
+		return 0.0, errUnderflow
+	}
+	if math.IsInf(r, 0) && !math.IsInf(value, 0) {
+		return 0.0, errOverflow
+	}
+	return r, nil
+}
+
+// ConvertSlice converts each quantity in src and stores the result in the
+// corresponding element of dst. dst and src must have the same length and may
+// be the same slice. On error, the contents of dst are unspecified.
+func (a *Converter) ConvertSlice(dst, src []float64) error {
+	if len(dst) != len(src) {
+		return errLengthMismatch
+	}
+	for idx, v := range src {
+		r, err := a.Convert(v)
+		if err != nil {
+			return err
+		}
+		dst[idx] = r
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestConverter(t *testing.T) {
+	type testCase struct {
+		From       string
+		To         string
+		Value      float64
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			From:     "m",
+			To:       "mm",
+			Value:    3.0,
+			Expected: 3000.0,
+		},
+		testCase{
+			From:     "g/ml",
+			To:       "mg/L",
+			Value:    1.0,
+			Expected: 1e6,
+		},
+		testCase{
+			From:     "ul",
+			To:       "ul",
+			Value:    2.5,
+			Expected: 2.5,
+		},
+		testCase{
+			From:     "U",
+			To:       "nkat",
+			Value:    60.0,
+			Expected: 1000.0,
+		},
+		testCase{
+			From:       "g",
+			To:         "g/l",
+			ShouldFail: true,
+		},
+		testCase{
+			From:       "g",
+			To:         "molk",
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		c, err := NewConverter(tc.From, tc.To)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error converting %q to %q", tc.From, tc.To)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("failed to convert %q to %q: %s", tc.From, tc.To, err)
+			continue
+		}
+
+		if f, err := c.Convert(tc.Value); err != nil {
+			t.Error(err)
+		} else if e := tc.Expected; e != f {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+
+		// Converter must agree with New
+		m, err := New(tc.To, Must(Parse(tc.Value, tc.From)))
+		if err != nil {
+			t.Error(err)
+		} else if f, _ := c.Convert(tc.Value); m.Quantity() != f {
+			t.Errorf("expecting %v found %v", m.Quantity(), f)
+		}
+	}
+}
+
+func TestConverterSlice(t *testing.T) {
+	c, err := NewConverter("ml", "ul")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := []float64{0.0, 1.0, 2.5}
+	if err := c.ConvertSlice(values, values); err != nil {
+		t.Error(err)
+	}
+	for idx, e := range []float64{0.0, 1000.0, 2500.0} {
+		if f := values[idx]; e != f {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+
+	if err := c.ConvertSlice(make([]float64, 1), values); err == nil {
+		t.Error("expecting error")
+	}
+}
+
+func TestConverterOverflow(t *testing.T) {
+	c, err := NewConverter("Yg^7", "yg^7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Convert(1.0); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+	if v, err := c.Convert(1e-100); err != nil {
+		t.Error(err)
+	} else if e, f := 1e236, v; math.Abs(e-f)/e > 1e-15 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	c, err = NewConverter("g", "dag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := c.Convert(5e-324); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+}
+
+func TestConverterAllocs(t *testing.T) {
+	c, err := NewConverter("ml", "ul")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make([]float64, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		if _, err := c.Convert(1.0); err != nil {
+			t.Fatal(err)
+		}
+		if err := c.ConvertSlice(values, values); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expecting no allocations found %v", allocs)
+	}
+}
+
+func BenchmarkConverter(b *testing.B) {
+	c, err := NewConverter("ml", "ul")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := c.Convert(float64(i)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkNew(b *testing.B) {
+	m := Must(Parse(1.0, "ml"))
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := New("ul", m); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
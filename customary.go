@@ -0,0 +1,87 @@
+package units
+
+// Customary units, in exact terms of SI units as defined by the 1959
+// international yard and pound agreement, the US Code of Federal Regulations
+// (US liquid measures) and the UK Weights and Measures Act 1985 (imperial
+// measures).
+
+// Units common to US customary and imperial units
+func makeCustomaryUnits() []keyedUnit {
+	length := mkpoint(de{lengthDim: 1})
+	mass := mkpoint(de{massDim: 1})
+
+	return []keyedUnit{
+		{Key: "in", Unit: &pUnit{Dim: length, Scale: -3, Factor: 25.4}, NoPrefix: true},
+		{Key: "ft", Unit: &pUnit{Dim: length, Scale: -3, Factor: 304.8}, NoPrefix: true},
+		{Key: "yd", Unit: &pUnit{Dim: length, Scale: -3, Factor: 914.4}, NoPrefix: true},
+		{Key: "mi", Unit: &pUnit{Dim: length, Factor: 1609.344}, NoPrefix: true},
+		{Key: "oz", Unit: &pUnit{Dim: mass, Factor: 28.349523125}, NoPrefix: true},
+		{Key: "lb", Unit: &pUnit{Dim: mass, Factor: 453.59237}, NoPrefix: true},
+	}
+}
+
+// US customary liquid measures. These are also available with a US prefix to
+// distinguish them from imperial measures.
+func makeUSUnits() []keyedUnit {
+	volume := mkpoint(de{lengthDim: 3})
+
+	var r []keyedUnit
+	for _, v := range []struct {
+		Key    string
+		Factor float64 // In ml
+		Alias  bool    // Also available with US prefix
+	}{
+		{Key: "gal", Factor: 3785.411784, Alias: true},
+		{Key: "qt", Factor: 946.352946, Alias: true},
+		{Key: "pt", Factor: 473.176473, Alias: true},
+		{Key: "fl oz", Factor: 29.5735295625, Alias: true},
+		{Key: "cup", Factor: 236.5882365},
+		{Key: "tbsp", Factor: 14.78676478125},
+		{Key: "tsp", Factor: 4.92892159375},
+	} {
+		unit := &pUnit{Dim: volume, Scale: -6, Factor: v.Factor}
+		r = append(r, keyedUnit{Key: v.Key, Unit: unit, NoPrefix: true})
+		if v.Alias {
+			r = append(r, keyedUnit{Key: "US " + v.Key, Unit: unit, NoPrefix: true})
+		}
+	}
+	return r
+}
+
+// Imperial liquid measures. These are always written with an imp prefix.
+func makeImperialUnits() []keyedUnit {
+	volume := mkpoint(de{lengthDim: 3})
+
+	var r []keyedUnit
+	for _, v := range []struct {
+		Key    string
+		Factor float64 // In ml
+	}{
+		{Key: "imp gal", Factor: 4546.09},
+		{Key: "imp qt", Factor: 1136.5225},
+		{Key: "imp pt", Factor: 568.26125},
+		{Key: "imp fl oz", Factor: 28.4130625},
+	} {
+		r = append(r, keyedUnit{
+			Key:      v.Key,
+			Unit:     &pUnit{Dim: volume, Scale: -6, Factor: v.Factor},
+			NoPrefix: true,
+		})
+	}
+	return r
+}
+
+// Nonlinear units common to US customary and imperial units
+func makeCustomaryNonlinearUnits() []keyedNonlinearUnit {
+	fahrenheit := &nonlinearUnit{
+		Reference: "°C",
+		Mapping: affineMapping{
+			Factor: 5.0 / 9.0,
+			Offset: -32,
+		},
+	}
+	return []keyedNonlinearUnit{
+		{Key: "°F", Unit: fahrenheit},
+		{Key: "℉", Unit: fahrenheit},
+	}
+}
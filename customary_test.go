@@ -0,0 +1,186 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCustomary(t *testing.T) {
+	type testCase struct {
+		Sets     UnitSet
+		Unit     string
+		From     string
+		Value    float64
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "mm",
+			From:     "in",
+			Value:    1.0,
+			Expected: 25.4,
+		},
+		testCase{
+			Sets:     Imperial,
+			Unit:     "in",
+			From:     "ft",
+			Value:    1.0,
+			Expected: 12.0,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "yd",
+			From:     "mi",
+			Value:    1.0,
+			Expected: 1760.0,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "oz",
+			From:     "lb",
+			Value:    1.0,
+			Expected: 16.0,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "kg",
+			From:     "lb",
+			Value:    1.0,
+			Expected: 0.45359237,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "in^3",
+			From:     "gal",
+			Value:    1.0,
+			Expected: 231.0,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "ml",
+			From:     "US fl oz",
+			Value:    1.0,
+			Expected: 29.5735295625,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "tsp",
+			From:     "cup",
+			Value:    1.0,
+			Expected: 48.0,
+		},
+		testCase{
+			Sets:     Imperial,
+			Unit:     "L",
+			From:     "imp gal",
+			Value:    1.0,
+			Expected: 4.54609,
+		},
+		testCase{
+			Sets:     USCustomary | Imperial,
+			Unit:     "imp fl oz",
+			From:     "imp gal",
+			Value:    1.0,
+			Expected: 160.0,
+		},
+		testCase{
+			Sets:     USCustomary | Imperial,
+			Unit:     "fl oz",
+			From:     "gal",
+			Value:    1.0,
+			Expected: 128.0,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "g/ml",
+			From:     "lb/gal",
+			Value:    1.0,
+			Expected: 453.59237 / 3785.411784,
+		},
+		testCase{
+			Sets:     USCustomary,
+			Unit:     "°C",
+			From:     "°F",
+			Value:    212.0,
+			Expected: 100.0,
+		},
+		testCase{
+			Sets:     Imperial,
+			Unit:     "℉",
+			From:     "°C",
+			Value:    37.0,
+			Expected: 98.6,
+		},
+	}
+
+	for _, tc := range suite {
+		r, err := NewRegistry(tc.Sets)
+		if err != nil {
+			t.Fatal(err)
+		}
+		from, err := r.Parse(tc.Value, tc.From)
+		if err != nil {
+			t.Errorf("%q: %s", tc.From, err)
+			continue
+		}
+		m, err := r.New(tc.Unit, from)
+		if err != nil {
+			t.Errorf("%q to %q: %s", tc.From, tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*math.Abs(e) {
+			t.Errorf("%q to %q: expecting %v found %v", tc.From, tc.Unit, e, f)
+		}
+	}
+}
+
+func TestCustomarySets(t *testing.T) {
+	// Customary units are opt-in
+	for _, u := range []string{"in", "oz", "gal", "°F"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+
+	us, err := NewRegistry(USCustomary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	imp, err := NewRegistry(Imperial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m, err := us.Parse(1.0, "imp gal"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := imp.Parse(1.0, "gal"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	// Customary units take no prefixes
+	for _, u := range []string{"kin", "mlb", "kgal"} {
+		if m, err := us.Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+
+	// SI units are unchanged; min is a minute, not a milli-inch
+	if m, err := us.New("s", Must(us.Parse(1.0, "min"))); err != nil {
+		t.Error(err)
+	} else if e, f := 60.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if c, err := us.NewConverter("ml", "μl"); err != nil {
+		t.Error(err)
+	} else if v, _ := c.Convert(1.0); v != 1000.0 {
+		t.Errorf("expecting 1000 found %v", v)
+	}
+
+	// Measurements from a registry can be used with the package-level
+	// functions
+	if m, err := New("g", Must(us.Parse(1.0, "lb"))); err != nil {
+		t.Error(err)
+	} else if e, f := 453.59237, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
@@ -0,0 +1,152 @@
+package units
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Exponent of a dimension defined with Registry.DefineDimension
+type userDim struct {
+	Label string
+	Exp   uComponent
+}
+
+// Encode exponents of user-defined dimensions as a string that is equal for
+// equal exponents, so that points can be compared with ==: each label in
+// sorted order is followed by a NUL and the two bytes of its exponent. Zero
+// exponents are left out. ds is sorted in place.
+func encodeUserDims(ds []userDim) string {
+	sort.Slice(ds, func(i, j int) bool {
+		return ds[i].Label < ds[j].Label
+	})
+	var b strings.Builder
+	for _, d := range ds {
+		if d.Exp.Sign() == 0 {
+			continue
+		}
+		b.WriteString(d.Label)
+		b.WriteByte(0)
+		b.WriteByte(byte(d.Exp.num))
+		b.WriteByte(byte(d.Exp.den))
+	}
+	return b.String()
+}
+
+// Decode exponents of user-defined dimensions
+func decodeUserDims(s string) []userDim {
+	var ds []userDim
+	for len(s) != 0 {
+		idx := strings.IndexByte(s, 0)
+		ds = append(ds, userDim{
+			Label: s[:idx],
+			Exp:   uComponent{num: int8(s[idx+1]), den: int8(s[idx+2])},
+		})
+		s = s[idx+3:]
+	}
+	return ds
+}
+
+// Sum encoded exponents of user-defined dimensions. Intermediate sums may be
+// out of range.
+func sumUserDims(ss ...string) (string, error) {
+	var labels []string
+	exps := make(map[string][]uComponent)
+	for _, s := range ss {
+		for _, d := range decodeUserDims(s) {
+			if _, ok := exps[d.Label]; !ok {
+				labels = append(labels, d.Label)
+			}
+			exps[d.Label] = append(exps[d.Label], d.Exp)
+		}
+	}
+	ds := make([]userDim, 0, len(labels))
+	for _, label := range labels {
+		e, err := sumComponents(exps[label]...)
+		if err != nil {
+			return "", err
+		}
+		ds = append(ds, userDim{Label: label, Exp: e})
+	}
+	return encodeUserDims(ds), nil
+}
+
+// DefineDimension adds a base dimension to the registry with the given
+// symbols for one unit of it, e.g., DefineDimension("well", "well", "wells").
+// Symbols take SI prefixes like other units. A symbol that already parses,
+// including as a prefixed unit like pl (picolitre), is rejected. Quantities
+// with different base dimensions cannot be converted into each other, so once
+// "tip" and "plate" are defined, New("tips/plate", ...) is dimension checked
+// like any other unit.
+//
+// The label identifies the dimension: registries that define the same label
+// share the dimension. There is no limit on the number of dimensions, and
+// measurements only carry the dimensions they use.
+func (r *Registry) DefineDimension(label string, symbols ...string) error {
+	if label == "" {
+		return errors.New("empty label")
+	}
+	if strings.IndexByte(label, 0) >= 0 {
+		return errors.New("invalid label " + strconv.Quote(label))
+	}
+	for _, l := range dimLabels {
+		if l == label {
+			return errors.New("dimension " + strconv.Quote(label) + " already exists")
+		}
+	}
+	if len(symbols) == 0 {
+		return errors.New("no symbols for " + strconv.Quote(label))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	units := append([]keyedUnit(nil), r.units...)
+	for _, key := range symbols {
+		if key == "" {
+			return errors.New("empty symbol for " + strconv.Quote(label))
+		}
+		units = append(units, keyedUnit{Key: key})
+	}
+	if err := checkKeys(units); err != nil {
+		return err
+	}
+	// A symbol that already parses, e.g., pl as picolitre, would change the
+	// meaning of existing unit strings
+	for _, key := range symbols {
+		if _, err := r.parseUnitString(key); err == nil {
+			return errors.New("symbol " + strconv.Quote(key) + " is already a unit")
+		}
+	}
+
+	unit := &pUnit{
+		Dim: uPoint{
+			user: encodeUserDims([]userDim{
+				{Label: label, Exp: intComponent(1)},
+			}),
+		},
+	}
+	units = units[:len(r.units)]
+	for _, key := range symbols {
+		units = append(units, keyedUnit{
+			Key:  key,
+			Unit: unit,
+		})
+	}
+	sort.Sort(keyedUnitSlice(units))
+
+	unitTrie := newTrie()
+	for idx, ku := range units {
+		unitTrie.insert(ku.Key, idx)
+	}
+	r.units = units
+	r.unitTrie = unitTrie
+
+	// New symbols can change how cached strings parse
+	r.cache.Range(func(key, _ interface{}) bool {
+		r.cache.Delete(key)
+		return true
+	})
+	return nil
+}
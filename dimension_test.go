@@ -0,0 +1,153 @@
+package units
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDefineDimension(t *testing.T) {
+	r, err := NewRegistry(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Not yet defined
+	if m, err := r.Parse(1.0, "µl/well"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	if err := r.DefineDimension("well", "well", "wells"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DefineDimension("tip", "tip", "tips"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.DefineDimension("plate", "plate", "plates"); err != nil {
+		t.Fatal(err)
+	}
+
+	perWell := Must(r.Parse(50.0, "µl/well"))
+	wells := Must(r.Parse(96.0, "wells"))
+	if m, err := r.New("ml", perWell, wells); err != nil {
+		t.Error(err)
+	} else if e, f := 4.8, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := r.New("ml", perWell); err != errWrongDimension {
+		t.Errorf("expecting %v got %v, %v", errWrongDimension, m, err)
+	}
+	if m, err := r.New("tips/plate", Must(r.Parse(1.0, "well/plate"))); err != errWrongDimension {
+		t.Errorf("expecting %v got %v, %v", errWrongDimension, m, err)
+	}
+	if m, err := r.New("tip/plate", Must(r.Parse(2.0, "ktips/plates"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2000.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	u, err := r.lookup("tips/plate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := "plate^-1 tip^1", u.product().String(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+
+	// Other registries share the dimension if they define the same label
+	other, err := NewRegistry(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := other.DefineDimension("well", "W96"); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := other.New("W96", wells); err != nil {
+		t.Error(err)
+	} else if e, f := 96.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	// Cancelling user-defined dimensions leaves a ratio of them
+	if m, err := r.New("µl/well", Must(r.Parse(2.0, "wells/tip")), Must(r.Parse(1.0, "µl·tips/wells^2"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := r.New("", Must(r.Parse(1.0, "wells/well"))); err != nil {
+		t.Error(err)
+	} else if e, f := 1.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := r.New("mol/mol", Must(r.Parse(1.0, "wells/well"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := r.Parse(1.0, "well^100·well^100"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	// The default registry is unchanged
+	if m, err := Parse(1.0, "well"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+
+	for _, tc := range []struct {
+		Label   string
+		Symbols []string
+	}{
+		{Label: "", Symbols: []string{"foo"}},
+		{Label: "cell", Symbols: []string{"foo"}},
+		{Label: "foo"},
+		{Label: "foo", Symbols: []string{"m"}},
+		{Label: "foo", Symbols: []string{"pH"}},
+		{Label: "foo", Symbols: []string{"wells"}},
+		{Label: "foo", Symbols: []string{"foo", "foo"}},
+		{Label: "plate", Symbols: []string{"pl"}},
+		{Label: "foo", Symbols: []string{"ks"}},
+		{Label: "foo", Symbols: []string{"cm"}},
+		{Label: "foo", Symbols: []string{"kg m"}},
+		{Label: "foo\x00", Symbols: []string{"foo"}},
+	} {
+		if err := r.DefineDimension(tc.Label, tc.Symbols...); err == nil {
+			t.Errorf("%q %q: expecting error", tc.Label, tc.Symbols)
+		}
+	}
+
+	// Prefixed units keep their meaning
+	if m, err := r.New("µl", Must(r.Parse(1.0, "pl"))); err != nil {
+		t.Error(err)
+	} else if e, f := 1e-6, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
+
+func TestManyDimensions(t *testing.T) {
+	// Dimensions are not a shared, limited resource
+	for i := 0; i < 4; i++ {
+		r, err := NewRegistry(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var units []Measurement
+		for j := 0; j < 16; j++ {
+			label := "dim" + strconv.Itoa(i) + "_" + strconv.Itoa(j)
+			if err := r.DefineDimension(label, label); err != nil {
+				t.Fatal(err)
+			}
+			units = append(units, Must(r.Parse(2.0, label)))
+		}
+		m, err := r.New("dim"+strconv.Itoa(i)+"_0", units[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, u := range units[1:] {
+			if m, err := r.New("dim"+strconv.Itoa(i)+"_0", u); err != errWrongDimension {
+				t.Errorf("expecting %v got %v, %v", errWrongDimension, m, err)
+			}
+		}
+		if m, err := r.New("", m, Must(Reciprocal(units[0]))); err != nil {
+			t.Error(err)
+		} else if e, f := 1.0, m.Quantity(); e != f {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
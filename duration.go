@@ -0,0 +1,32 @@
+package units
+
+import (
+	"math"
+	"time"
+)
+
+// FromDuration returns a duration as a measurement in ns. Durations of up to
+// 2^53 ns (about 104 days) are represented exactly.
+func FromDuration(d time.Duration) Measurement {
+	return Must(Parse(float64(d), "ns"))
+}
+
+// ToDuration converts the product of measurements to a duration, rounding to
+// the nearest nanosecond. The product must have the dimension of time, so,
+// for example, the time to dispense a volume at a rate is
+//
+//   ToDuration(volume, Must(Reciprocal(rate)))
+//
+// An error is returned if the duration does not fit in a time.Duration.
+func ToDuration(m0 Measurement, ms ...Measurement) (time.Duration, error) {
+	m, err := New("ns", m0, ms...)
+	if err != nil {
+		return 0, err
+	}
+	v := math.Round(m.Quantity())
+	// float64(math.MaxInt64) rounds up to 2^63, which is out of range
+	if !(v >= math.MinInt64 && v < math.MaxInt64) {
+		return 0, errOverflow
+	}
+	return time.Duration(v), nil
+}
@@ -0,0 +1,106 @@
+package units
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	type testCase struct {
+		Value      Measurement
+		Rate       Measurement
+		Expected   time.Duration
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Value:    Must(Parse(30.0, "s")),
+			Expected: 30 * time.Second,
+		},
+		testCase{
+			Value:    Must(Parse(2.0, "ms")),
+			Expected: 2 * time.Millisecond,
+		},
+		testCase{
+			Value:    Must(Parse(1.5, "h")),
+			Expected: 90 * time.Minute,
+		},
+		testCase{
+			Value:    Must(Parse(1.0, "min")),
+			Expected: time.Minute,
+		},
+		testCase{
+			Value:    Must(Parse(0.4, "ns")),
+			Expected: 0,
+		},
+		testCase{
+			Value:    Must(Parse(-1.5, "ns")),
+			Expected: -2,
+		},
+		testCase{
+			Value:    Must(Parse(100.0, "µl")),
+			Rate:     Must(Parse(20.0, "µl/s")),
+			Expected: 5 * time.Second,
+		},
+		testCase{
+			Value:    Must(Parse(1.0, "ml")),
+			Rate:     Must(Parse(3.0, "ul/min")),
+			Expected: time.Duration(math.Round(1e3 / 3 * 60 * 1e9)),
+		},
+		testCase{
+			Value:      Must(Parse(1.0, "ml")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:      Must(Parse(300.0, "Ts")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:      Must(Parse(float64(math.MaxInt64), "ns")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:    Must(Parse(0.0, "µl")),
+			Rate:     Must(Parse(2.0, "µl/s")),
+			Expected: 0,
+		},
+	}
+
+	for _, tc := range suite {
+		var ms []Measurement
+		if tc.Rate != nil {
+			ms = append(ms, Must(Reciprocal(tc.Rate)))
+		}
+		d, err := ToDuration(tc.Value, ms...)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("%v %s: expecting error got %v", tc.Value.Quantity(), tc.Value.MeasurementUnit(), d)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v %s: %s", tc.Value.Quantity(), tc.Value.MeasurementUnit(), err)
+		} else if e, f := tc.Expected, d; e != f {
+			t.Errorf("%v %s: expecting %v found %v", tc.Value.Quantity(), tc.Value.MeasurementUnit(), e, f)
+		}
+	}
+}
+
+func TestFromDuration(t *testing.T) {
+	for _, d := range []time.Duration{0, time.Nanosecond, -time.Hour, 1<<53 - 1} {
+		m := FromDuration(d)
+		if f, err := ToDuration(m); err != nil {
+			t.Error(err)
+		} else if d != f {
+			t.Errorf("expecting %v found %v", d, f)
+		}
+	}
+
+	if m, err := New("min", FromDuration(90*time.Second)); err != nil {
+		t.Error(err)
+	} else if e, f := 1.5, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
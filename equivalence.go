@@ -0,0 +1,80 @@
+package units
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Densities of common liquids at 20 °C in g/ml
+var liquidDensities = map[string]float64{
+	"water":    0.99820,
+	"ethanol":  0.78945,
+	"glycerol": 1.2613,
+	"dmso":     1.1004,
+}
+
+// An Equivalence relates quantities of two dimensions that cannot otherwise be
+// converted between each other, such as the mass and volume of a liquid.
+type Equivalence struct {
+	ratio *measure
+}
+
+// Density returns the equivalence between mass and volume given by a density
+// (e.g., in g/ml).
+func Density(density Measurement) (Equivalence, error) {
+	m, err := parse(density)
+	if err != nil {
+		return Equivalence{}, err
+	}
+	if !hasDimension(m, "g/ml") {
+		return Equivalence{}, errWrongDimension
+	}
+	if m.Value == 0.0 {
+		return Equivalence{}, errDivideByZero
+	}
+	return Equivalence{ratio: m}, nil
+}
+
+// Liquid returns the equivalence between mass and volume of a named liquid at
+// 20 °C. Known liquids are water, ethanol, glycerol and DMSO; names are not
+// case sensitive.
+func Liquid(name string) (Equivalence, error) {
+	density, ok := liquidDensities[strings.ToLower(name)]
+	if !ok {
+		return Equivalence{}, errors.New("unknown liquid " + strconv.Quote(name))
+	}
+	return Density(Must(Parse(density, "g/ml")))
+}
+
+// Ratio returns the ratio of the equivalence, e.g., the density.
+func (a Equivalence) Ratio() Measurement {
+	if a.ratio == nil {
+		return zeroValue
+	}
+	return a.ratio
+}
+
+// NewEquivalent is like New but additionally applies the equivalence, in
+// either direction, if the product of the measurements does not have the
+// dimensions of unitString. E.g., with the density of water, a mass in g can
+// be converted to a volume in ml.
+func NewEquivalent(unitString string, eq Equivalence, m0 Measurement, ms ...Measurement) (Measurement, error) {
+	m, err := New(unitString, m0, ms...)
+	if err != errWrongDimension || eq.ratio == nil {
+		return m, err
+	}
+
+	inverse, err := Reciprocal(eq.ratio)
+	if err != nil {
+		return zeroValue, err
+	}
+	for _, bridge := range []Measurement{eq.ratio, inverse} {
+		rest := append(append([]Measurement(nil), ms...), bridge)
+		m, err := New(unitString, m0, rest...)
+		if err != errWrongDimension {
+			return m, err
+		}
+	}
+	return zeroValue, errWrongDimension
+}
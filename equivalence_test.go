@@ -0,0 +1,106 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewEquivalent(t *testing.T) {
+	water, err := Liquid("water")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dmso, err := Liquid("DMSO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom, err := Density(Must(Parse(2.0, "kg/L")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type testCase struct {
+		Unit       string
+		Eq         Equivalence
+		From       []Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			// Gravimetric pipette calibration
+			Unit:     "ul",
+			Eq:       water,
+			From:     []Measurement{Must(Parse(99.82, "mg"))},
+			Expected: 100.0,
+		},
+		testCase{
+			Unit:     "g",
+			Eq:       dmso,
+			From:     []Measurement{Must(Parse(10.0, "ml"))},
+			Expected: 11.004,
+		},
+		testCase{
+			Unit:     "ml",
+			Eq:       custom,
+			From:     []Measurement{Must(Parse(5.0, "g"))},
+			Expected: 2.5,
+		},
+		testCase{
+			// Dimensions already agree; equivalence is not used
+			Unit:     "mg",
+			Eq:       custom,
+			From:     []Measurement{Must(Parse(5.0, "g"))},
+			Expected: 5000.0,
+		},
+		testCase{
+			// Volume flow from mass flow
+			Unit:     "ml/s",
+			Eq:       custom,
+			From:     []Measurement{Must(Parse(2.0, "g")), Must(Parse(2.0, "s^-1"))},
+			Expected: 2.0,
+		},
+		testCase{
+			Unit:       "mol",
+			Eq:         water,
+			From:       []Measurement{Must(Parse(5.0, "g"))},
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "ml",
+			From:       []Measurement{Must(Parse(5.0, "g"))},
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := NewEquivalent(tc.Unit, tc.Eq, tc.From[0], tc.From[1:]...)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+		} else if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestDensity(t *testing.T) {
+	if eq, err := Density(Must(Parse(1.0, "g/m"))); err == nil {
+		t.Errorf("expecting error got %v", eq)
+	}
+	if eq, err := Density(Must(Parse(0.0, "g/ml"))); err == nil {
+		t.Errorf("expecting error got %v", eq)
+	}
+	if eq, err := Liquid("mercury"); err == nil {
+		t.Errorf("expecting error got %v", eq)
+	}
+	if eq, err := Liquid("Glycerol"); err != nil {
+		t.Error(err)
+	} else if e, f := 1.2613, eq.Ratio().Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
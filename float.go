@@ -0,0 +1,81 @@
+package units
+
+import (
+	"math"
+)
+
+// Largest power of ten that is exactly representable as a float64
+const maxExactPow10 = 22
+
+// A floating point value with an extended exponent range. The value is
+// frac·2^exp2·10^exp10. Multiplying extFloats never overflows or underflows;
+// range is only checked when collapsing back to a float64.
+type extFloat struct {
+	frac  float64 // Zero, infinite, NaN or in [0.5, 1)
+	exp2  int
+	exp10 int
+}
+
+func makeExtFloat(v float64) extFloat {
+	frac, exp := math.Frexp(v)
+	return extFloat{
+		frac: frac,
+		exp2: exp,
+	}
+}
+
+func (a extFloat) Multiply(b extFloat) extFloat {
+	frac, exp := math.Frexp(a.frac * b.frac)
+	return extFloat{
+		frac:  frac,
+		exp2:  a.exp2 + b.exp2 + exp,
+		exp10: a.exp10 + b.exp10,
+	}
+}
+
+func (a extFloat) MultiplyPow10(n int) extFloat {
+	a.exp10 += n
+	return a
+}
+
+// Return the value as a float64. Values out of range become zero or infinity.
+func (a extFloat) Float64() float64 {
+	// Fast path: when everything is in range, this computes exactly what
+	// plain float64 arithmetic would.
+	x := math.Ldexp(a.frac, a.exp2)
+	p := math.Pow10(a.exp10)
+	if r := x * p; inRange(x) && inRange(p) && inRange(r) {
+		return r
+	}
+
+	if a.frac == 0.0 || math.IsInf(a.frac, 0) || math.IsNaN(a.frac) {
+		return a.frac
+	}
+
+	// Slow path: fold the decimal exponent into the binary one in exactly
+	// representable steps
+	frac, exp2 := a.frac, a.exp2
+	for n := a.exp10; n != 0; {
+		k := n
+		if k > maxExactPow10 {
+			k = maxExactPow10
+		} else if k < -maxExactPow10 {
+			k = -maxExactPow10
+		}
+		if k > 0 {
+			frac *= math.Pow10(k)
+		} else {
+			frac /= math.Pow10(-k)
+		}
+		var exp int
+		frac, exp = math.Frexp(frac)
+		exp2 += exp
+		n -= k
+	}
+	return math.Ldexp(frac, exp2)
+}
+
+// Return if value is non-zero and finite
+func inRange(v float64) bool {
+	return v != 0.0 && !math.IsInf(v, 0) && !math.IsNaN(v)
+}
@@ -0,0 +1,65 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExtFloat(t *testing.T) {
+	type testCase struct {
+		Values   []float64
+		Pow10    int
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Values:   []float64{3.0},
+			Pow10:    3,
+			Expected: 3000.0,
+		},
+		testCase{
+			Values:   []float64{3.0, 1.0 / 3.0},
+			Pow10:    6,
+			Expected: 1e6,
+		},
+		testCase{
+			Values:   []float64{1e300, 1e300, 1e300},
+			Pow10:    -800,
+			Expected: 1e100,
+		},
+		testCase{
+			Values:   []float64{1e-300, 1e-300},
+			Pow10:    400,
+			Expected: 1e-200,
+		},
+		testCase{
+			Values:   []float64{math.MaxFloat64, 2.0},
+			Expected: math.Inf(1),
+		},
+		testCase{
+			Values:   []float64{1e-300},
+			Pow10:    -100,
+			Expected: 0.0,
+		},
+		testCase{
+			Values:   []float64{0.0, 1e300},
+			Pow10:    400,
+			Expected: 0.0,
+		},
+	}
+
+	for _, tc := range suite {
+		v := makeExtFloat(1.0)
+		for _, x := range tc.Values {
+			v = v.Multiply(makeExtFloat(x))
+		}
+		e, f := tc.Expected, v.MultiplyPow10(tc.Pow10).Float64()
+		if e == f {
+			continue
+		}
+		if !inRange(e) || math.Abs(e-f)/math.Abs(e) > 1e-15 {
+			t.Errorf("%v·10^%d: expecting %v found %v", tc.Values, tc.Pow10, e, f)
+		}
+	}
+}
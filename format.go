@@ -0,0 +1,86 @@
+package units
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Replacements of non-ASCII runes in units for the # flag
+var asciiReplacer = strings.NewReplacer(
+	"μ", "u",
+	"µ", "u",
+	"·", " ",
+	"Ω", "ohm",
+	"℃", "degC",
+	"℉", "degF",
+	"°", "deg",
+	"′", "arcmin",
+	"″", "arcsec",
+	"×", "x",
+	"Θ", "Th",
+)
+
+// String formats a measurement as its quantity and unit, e.g., "1.5 ml".
+func (a *measure) String() string {
+	s := strconv.FormatFloat(a.Value, 'g', -1, 64)
+	if a.Unit != "" {
+		s += " " + a.Unit
+	}
+	return s
+}
+
+// Format implements fmt.Formatter. The verbs v, s, e, E, f, F, g and G format
+// the quantity as for a float64, with v and s as g, followed by the unit.
+// Width, precision and the flags +, -, space and 0 apply to the quantity. The
+// # flag writes the unit in ASCII (e.g., ul rather than μl), and the + flag
+// with v also writes the dimension, e.g., "1 mg/ml [L^-3 M^1]".
+func (a *measure) Format(f fmt.State, verb rune) {
+	numVerb := verb
+	switch verb {
+	case 'v', 's':
+		numVerb = 'g'
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+	default:
+		fmt.Fprintf(f, "%%!%c(%s)", verb, a.String())
+		return
+	}
+
+	showDim := verb == 'v' && f.Flag('+')
+	ascii := f.Flag('#')
+
+	spec := "%"
+	for _, flag := range "+- 0" {
+		if flag == '+' && showDim {
+			continue
+		}
+		if f.Flag(int(flag)) {
+			spec += string(flag)
+		}
+	}
+	if w, ok := f.Width(); ok {
+		spec += strconv.Itoa(w)
+	}
+	if p, ok := f.Precision(); ok {
+		spec += "." + strconv.Itoa(p)
+	}
+	spec += string(numVerb)
+
+	s := fmt.Sprintf(spec, a.Value)
+
+	unit := a.Unit
+	if ascii {
+		unit = asciiReplacer.Replace(unit)
+	}
+	if unit != "" {
+		s += " " + unit
+	}
+	if showDim && a.unit != nil {
+		dim := a.unit.product().String()
+		if ascii {
+			dim = asciiReplacer.Replace(dim)
+		}
+		s += " [" + dim + "]"
+	}
+	fmt.Fprint(f, s)
+}
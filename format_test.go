@@ -0,0 +1,93 @@
+package units
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	type testCase struct {
+		Format   string
+		Value    Measurement
+		Expected string
+	}
+
+	suite := []testCase{
+		testCase{
+			Format:   "%v",
+			Value:    Must(Parse(1.5, "ml")),
+			Expected: "1.5 ml",
+		},
+		testCase{
+			Format:   "%s",
+			Value:    Must(Parse(2.9999999999999996, "ml")),
+			Expected: "2.9999999999999996 ml",
+		},
+		testCase{
+			Format:   "%.2f",
+			Value:    Must(Parse(2.9999999999999996, "ml")),
+			Expected: "3.00 ml",
+		},
+		testCase{
+			Format:   "%e",
+			Value:    Must(Parse(1500.0, "μl")),
+			Expected: "1.500000e+03 μl",
+		},
+		testCase{
+			Format:   "%8.3g|",
+			Value:    Must(Parse(3.14159, "rad")),
+			Expected: "    3.14 rad|",
+		},
+		testCase{
+			Format:   "%-6v|",
+			Value:    Must(Parse(1.0, "m")),
+			Expected: "1      m|",
+		},
+		testCase{
+			Format:   "%+.1f",
+			Value:    Must(Parse(37.0, "°C")),
+			Expected: "+37.0 °C",
+		},
+		testCase{
+			Format:   "%+v",
+			Value:    Must(Parse(1.0, "mg/ml")),
+			Expected: "1 mg/ml [L^-3 M^1]",
+		},
+		testCase{
+			Format:   "%#v",
+			Value:    Must(Parse(5.0, "μl·Ω")),
+			Expected: "5 ul ohm",
+		},
+		testCase{
+			Format:   "%#+v",
+			Value:    Must(Parse(20.0, "°C")),
+			Expected: "20 degC [ThC^1]",
+		},
+		testCase{
+			Format:   "%v",
+			Value:    Must(Parse(0.5, "")),
+			Expected: "0.5",
+		},
+		testCase{
+			Format:   "%v",
+			Value:    Must(Reciprocal(Must(Parse(2.0, "m/s")))),
+			Expected: "0.5 s/m",
+		},
+		testCase{
+			Format:   "%d",
+			Value:    Must(Parse(1.0, "m")),
+			Expected: "%!d(1 m)",
+		},
+	}
+
+	for _, tc := range suite {
+		if e, f := tc.Expected, fmt.Sprintf(tc.Format, tc.Value); e != f {
+			t.Errorf("%q: expecting %q found %q", tc.Format, e, f)
+		}
+	}
+
+	m := Must(Parse(1.5, "ml"))
+	if e, f := "1.5 ml", m.(fmt.Stringer).String(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+}
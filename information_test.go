@@ -0,0 +1,96 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestInformation(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		From       Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "bit",
+			From:     Must(Parse(1.0, "B")),
+			Expected: 8.0,
+		},
+		testCase{
+			Unit:     "B",
+			From:     Must(Parse(1.0, "kB")),
+			Expected: 1000.0,
+		},
+		testCase{
+			Unit:     "B",
+			From:     Must(Parse(1.0, "KiB")),
+			Expected: 1024.0,
+		},
+		testCase{
+			Unit:     "KiB",
+			From:     Must(Parse(1.0, "MiB")),
+			Expected: 1024.0,
+		},
+		testCase{
+			Unit:     "MB",
+			From:     Must(Parse(1.0, "GiB")),
+			Expected: 1073.741824,
+		},
+		testCase{
+			Unit:     "B",
+			From:     Must(Parse(1.0, "YiB")),
+			Expected: 1208925819614629174706176.0,
+		},
+		testCase{
+			Unit:     "MiB/s",
+			From:     Must(Parse(8.0, "Mibit/s")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "Mbit/s",
+			From:     Must(Parse(1.0, "kB/ms")),
+			Expected: 8.0,
+		},
+		testCase{
+			Unit:       "B",
+			From:       Must(Parse(1.0, "")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("%v %s to %q: expecting error got %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v %s to %q: %s", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("%v %s to %q: expecting %v found %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+
+	// Binary prefixes only apply to information
+	for _, u := range []string{"Kim", "Mig", "Gis", "KB"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+
+	// Information takes no prefixes below k
+	for _, u := range []string{"dB/s", "dB s", "cB", "mbit", "hB", "daB", "ubit"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+	if m, err := New("", Must(Parse(20.0, "dB"))); err != nil {
+		t.Error(err)
+	} else if e, f := 100.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
@@ -2,18 +2,121 @@ package units
 
 import (
 	"errors"
+	"math"
 	"sort"
 	"strconv"
+	"sync"
 )
 
 var (
-	defaultScales []keyedScale
-	defaultUnits  []keyedUnit
+	defaultScales         []keyedScale
+	defaultUnits          []keyedUnit
+	defaultNonlinearUnits []keyedNonlinearUnit
+	defaultRegistry       *Registry
 )
 
+// A Registry is a set of prefixes and unit symbols that can be parsed. The
+// package-level functions use a default registry of SI and common
+// laboratory units; use NewRegistry for registries that also accept
+// customary units. A Registry is safe for concurrent use.
+type Registry struct {
+	scales         []keyedScale
+	units          []keyedUnit
+	nonlinearUnits map[string]*nonlinearUnit
+	scaleTrie      *trie
+	unitTrie       *trie
+	// Guards the tables above, which DefineDimension may extend
+	mu sync.RWMutex
+	// Parsed unit strings (map[string]*pUnit). Parsed units are never
+	// modified once constructed, so entries can be shared between
+	// measurements and goroutines. Only successful parses are cached.
+	cache sync.Map
+}
+
+// A UnitSet selects optional units to add to a Registry
+type UnitSet int
+
+// Optional unit sets. Units common to both sets, like in, lb and °F, are
+// added by either one. Where US customary and imperial units share a name,
+// the plain symbol (e.g., gal or fl oz) is the US unit; imperial units are
+// always written with an imp prefix (e.g., imp gal).
+const (
+	USCustomary UnitSet = 1 << iota // US customary units (in, lb, gal, fl oz, ...)
+	Imperial                        // Imperial units (in, lb, imp gal, imp fl oz, ...)
+)
+
+// NewRegistry returns a Registry with the default units and the given
+// optional unit sets. Customary units never take SI prefixes.
+func NewRegistry(sets UnitSet) (*Registry, error) {
+	units := append([]keyedUnit(nil), defaultUnits...)
+	if sets&(USCustomary|Imperial) != 0 {
+		units = append(units, makeCustomaryUnits()...)
+	}
+	if sets&USCustomary != 0 {
+		units = append(units, makeUSUnits()...)
+	}
+	if sets&Imperial != 0 {
+		units = append(units, makeImperialUnits()...)
+	}
+	if err := checkKeys(units); err != nil {
+		return nil, err
+	}
+	sort.Sort(keyedUnitSlice(units))
+
+	nonlinearUnits := append([]keyedNonlinearUnit(nil), defaultNonlinearUnits...)
+	if sets&(USCustomary|Imperial) != 0 {
+		nonlinearUnits = append(nonlinearUnits, makeCustomaryNonlinearUnits()...)
+	}
+
+	return newRegistry(defaultScales, units, nonlinearUnits)
+}
+
+func newRegistry(scales []keyedScale, units []keyedUnit, nonlinearUnits []keyedNonlinearUnit) (*Registry, error) {
+	r := &Registry{
+		scales:         scales,
+		units:          units,
+		nonlinearUnits: make(map[string]*nonlinearUnit),
+		scaleTrie:      newTrie(),
+		unitTrie:       newTrie(),
+	}
+	for idx, ks := range scales {
+		r.scaleTrie.insert(ks.Key, idx)
+	}
+	for idx, ku := range units {
+		r.unitTrie.insert(ku.Key, idx)
+	}
+	// Reference units are parsed with the registry itself, so they can only
+	// be added once the symbols are in place
+	for _, kn := range nonlinearUnits {
+		ref, err := r.parseUnitString(kn.Unit.Reference)
+		if err != nil {
+			return nil, err
+		}
+		nu := *kn.Unit
+		nu.ref = ref
+		r.nonlinearUnits[kn.Key] = &nu
+	}
+	return r, nil
+}
+
+// Return an error if any key is repeated
+func checkKeys(units []keyedUnit) error {
+	seen := make(map[string]bool)
+	for _, v := range units {
+		if seen[v.Key] {
+			return errors.New("duplicate key " + strconv.Quote(v.Key))
+		}
+		seen[v.Key] = true
+	}
+	return nil
+}
+
 type keyedUnit struct {
-	Key  string
-	Unit *pUnit
+	Key          string
+	Unit         *pUnit
+	NoPrefix     bool // Symbol cannot take a prefix
+	BinaryPrefix bool // Symbol can also take a binary prefix
+	LargePrefix  bool // Symbol only takes prefixes of k and above
 }
 
 type keyedUnitSlice []keyedUnit
@@ -30,9 +133,17 @@ func (a keyedUnitSlice) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
+type keyedNonlinearUnit struct {
+	Key  string
+	Unit *nonlinearUnit
+}
+
 type keyedScale struct {
 	Key   string
 	Scale int
+	// For binary prefixes, the multiplier in place of Scale. Binary prefixes
+	// only apply to symbols with BinaryPrefix set.
+	Factor float64
 }
 
 type keyedScaleSlice []keyedScale
@@ -49,8 +160,8 @@ func (a keyedScaleSlice) Swap(i, j int) {
 	a[i], a[j] = a[j], a[i]
 }
 
-// Sort longer strings before shorter strings. This ensures that we find the
-// longest match first.
+// Sort longer strings before shorter strings. Matching is done with a trie so
+// the order only affects how the tables are listed.
 func longLess(a, b string) bool {
 	la, lb := len(a), len(b)
 	if la == lb {
@@ -77,6 +188,7 @@ func makeScales() ([]keyedScale, error) {
 	r = append(r, keyedScale{Key: "c", Scale: -2})
 	r = append(r, keyedScale{Key: "m", Scale: -3})
 	r = append(r, keyedScale{Key: "μ", Scale: -6})
+	r = append(r, keyedScale{Key: "µ", Scale: -6}) // Micro sign (U+00B5)
 	r = append(r, keyedScale{Key: "u", Scale: -6})
 	r = append(r, keyedScale{Key: "n", Scale: -9})
 	r = append(r, keyedScale{Key: "p", Scale: -12})
@@ -85,6 +197,11 @@ func makeScales() ([]keyedScale, error) {
 	r = append(r, keyedScale{Key: "z", Scale: -21})
 	r = append(r, keyedScale{Key: "y", Scale: -24})
 
+	// Binary prefixes (IEC 80000-13)
+	for idx, key := range []string{"Ki", "Mi", "Gi", "Ti", "Pi", "Ei", "Zi", "Yi"} {
+		r = append(r, keyedScale{Key: key, Factor: math.Ldexp(1, 10*(idx+1))})
+	}
+
 	seen := make(map[string]bool)
 	for _, v := range r {
 		if seen[v.Key] {
@@ -98,16 +215,17 @@ func makeScales() ([]keyedScale, error) {
 	return r, nil
 }
 
-func makeUnits() ([]keyedUnit, error) {
-	type de map[int]uComponent
+// Integer dimension exponents by dimension
+type de map[int]int
 
-	mkpoint := func(m de) (a uPoint) {
-		for d, e := range m {
-			a[d] = e
-		}
-		return
+func mkpoint(m de) (a uPoint) {
+	for d, e := range m {
+		a.base[d] = intComponent(e)
 	}
+	return
+}
 
+func makeUnits() ([]keyedUnit, error) {
 	var r keyedUnitSlice
 
 	r = append(r, keyedUnit{
@@ -170,6 +288,7 @@ func makeUnits() ([]keyedUnit, error) {
 					lengthDim: -1,
 				}),
 			},
+			Kinds: mkkind(angleKind),
 		}})
 	r = append(r, keyedUnit{
 		Key: "sr",
@@ -183,12 +302,43 @@ func makeUnits() ([]keyedUnit, error) {
 				}),
 			},
 		}})
+	// Angles
+	for _, v := range []struct {
+		Keys   []string
+		Factor float64 // In rad
+	}{
+		{Keys: []string{"deg", "°"}, Factor: math.Pi / 180},
+		{Keys: []string{"arcmin", "′"}, Factor: math.Pi / 10800},
+		{Keys: []string{"arcsec", "″"}, Factor: math.Pi / 648000},
+		{Keys: []string{"rev"}, Factor: 2 * math.Pi},
+		{Keys: []string{"gon"}, Factor: math.Pi / 200},
+	} {
+		unit := &pUnit{
+			DimLess: []uPoint{
+				mkpoint(de{
+					lengthDim: 1,
+				}),
+				mkpoint(de{
+					lengthDim: -1,
+				}),
+			},
+			Factor: v.Factor,
+			Kinds:  mkkind(angleKind),
+		}
+		for _, key := range v.Keys {
+			r = append(r, keyedUnit{
+				Key:  key,
+				Unit: unit,
+			})
+		}
+	}
 	r = append(r, keyedUnit{
 		Key: "Hz",
 		Unit: &pUnit{
 			Dim: mkpoint(de{
 				timeDim: -1,
 			}),
+			Kinds: mkkind(frequencyKind),
 		}})
 	r = append(r, keyedUnit{
 		Key: "N",
@@ -391,6 +541,7 @@ func makeUnits() ([]keyedUnit, error) {
 					timeDim: -1,
 				},
 			),
+			Kinds: mkkind(activityKind),
 		}})
 	r = append(r, keyedUnit{
 		Key: "Gy",
@@ -401,6 +552,7 @@ func makeUnits() ([]keyedUnit, error) {
 					timeDim:   -2,
 				},
 			),
+			Kinds: mkkind(absorbedDoseKind),
 		}})
 	r = append(r, keyedUnit{
 		Key: "Sv",
@@ -411,6 +563,7 @@ func makeUnits() ([]keyedUnit, error) {
 					timeDim:   -2,
 				},
 			),
+			Kinds: mkkind(doseEquivalentKind),
 		}})
 	r = append(r, keyedUnit{
 		Key: "kat",
@@ -452,6 +605,283 @@ func makeUnits() ([]keyedUnit, error) {
 				},
 			),
 		}})
+	r = append(r, keyedUnit{
+		Key: "min",
+		Unit: &pUnit{
+			Dim: mkpoint(
+				de{
+					timeDim: 1,
+				},
+			),
+			Factor: 60,
+		}})
+	r = append(r, keyedUnit{
+		Key: "h",
+		Unit: &pUnit{
+			Dim: mkpoint(
+				de{
+					timeDim: 1,
+				},
+			),
+			Factor: 3600,
+		}})
+
+	// Rotational speed: revolutions per minute
+	r = append(r, keyedUnit{
+		Key: "rpm",
+		Unit: &pUnit{
+			Dim: mkpoint(
+				de{
+					timeDim: -1,
+				},
+			),
+			Factor: 1.0 / 60.0,
+			Kinds:  mkkind(frequencyKind),
+		}})
+
+	// Relative centrifugal force: multiples of standard gravity
+	for _, key := range []string{"×g", "xg"} {
+		r = append(r, keyedUnit{
+			Key: key,
+			Unit: &pUnit{
+				Dim: mkpoint(
+					de{
+						lengthDim: 1,
+						timeDim:   -2,
+					},
+				),
+				Factor: standardGravity,
+			}})
+	}
+
+	// Molar concentration
+	r = append(r, keyedUnit{
+		Key: "M",
+		Unit: &pUnit{
+			Dim: mkpoint(
+				de{
+					amountDim: 1,
+					lengthDim: -3,
+				},
+			),
+			Scale: 3,
+		}})
+
+	// Counts. Each is its own dimension so that, e.g., cells/ml cannot be
+	// confused with bp/ml or ml^-1. Base pairs and nucleotides both measure
+	// sequence length.
+	for _, ku := range []struct {
+		Keys []string
+		Dim  int
+	}{
+		{Keys: []string{"bp", "nt"}, Dim: nucleotideDim},
+		{Keys: []string{"cells", "cell"}, Dim: cellDim},
+		{Keys: []string{"CFU", "cfu"}, Dim: colonyDim},
+		{Keys: []string{"copies", "copy"}, Dim: copyDim},
+	} {
+		unit := &pUnit{
+			Dim: mkpoint(de{
+				ku.Dim: 1,
+			}),
+		}
+		for _, key := range ku.Keys {
+			r = append(r, keyedUnit{
+				Key:  key,
+				Unit: unit,
+			})
+		}
+	}
+
+	// Information. Symbols take both SI and binary prefixes, so kB is 1000 B
+	// and KiB is 1024 B. Fractions of a bit are meaningless, and dB would
+	// read as a decibyte, so there are no prefixes below k.
+	r = append(r, keyedUnit{
+		Key: "bit",
+		Unit: &pUnit{
+			Dim: mkpoint(de{
+				informationDim: 1,
+			}),
+		},
+		BinaryPrefix: true,
+		LargePrefix:  true,
+	})
+	r = append(r, keyedUnit{
+		Key: "B",
+		Unit: &pUnit{
+			Dim: mkpoint(de{
+				informationDim: 1,
+			}),
+			Factor: 8,
+		},
+		BinaryPrefix: true,
+		LargePrefix:  true,
+	})
+
+	// Enzyme activity: 1 U = 1 μmol/min = 1/60 μkat
+	for _, key := range []string{"U", "IU"} {
+		r = append(r, keyedUnit{
+			Key: key,
+			Unit: &pUnit{
+				Dim: mkpoint(
+					de{
+						amountDim: 1,
+						timeDim:   -1,
+					},
+				),
+				Scale:  -6,
+				Factor: 1.0 / 60.0,
+			}})
+	}
+
+	// Dimensionless ratios
+	r = append(r, keyedUnit{
+		Key: "%",
+		Unit: &pUnit{
+			Scale: -2,
+		}})
+	r = append(r, keyedUnit{
+		Key: "ppm",
+		Unit: &pUnit{
+			Scale: -6,
+		}})
+	r = append(r, keyedUnit{
+		Key: "ppb",
+		Unit: &pUnit{
+			Scale: -9,
+		}})
+
+	// Multiple of a working concentration, e.g., 10X buffer
+	r = append(r, keyedUnit{
+		Key: "X",
+		Unit: &pUnit{
+			Kinds: mkkind(stockMultipleKind),
+		},
+	})
+
+	// Absorbance (optical density)
+	for _, key := range []string{"AU", "OD"} {
+		r = append(r, keyedUnit{
+			Key: key,
+			Unit: &pUnit{
+				Kinds: mkkind(absorbanceKind),
+			},
+		})
+	}
+
+	// Percent solutions. Mass and volume fractions are ratios of the same
+	// dimension, so they are kept apart by their dimensionless factors.
+	// Mass per volume is g/100 ml.
+	for _, kind := range []string{"w/w", "v/v", "w/v"} {
+		var unit *pUnit
+		switch kind {
+		case "w/w":
+			unit = &pUnit{
+				DimLess: []uPoint{
+					mkpoint(de{
+						massDim: 1,
+					}),
+					mkpoint(de{
+						massDim: -1,
+					}),
+				},
+				Scale: -2,
+			}
+		case "v/v":
+			unit = &pUnit{
+				DimLess: []uPoint{
+					mkpoint(de{
+						lengthDim: 3,
+					}),
+					mkpoint(de{
+						lengthDim: -3,
+					}),
+				},
+				Scale: -2,
+			}
+		case "w/v":
+			unit = &pUnit{
+				Dim: mkpoint(
+					de{
+						massDim:   1,
+						lengthDim: -3,
+					},
+				),
+				Scale: 4,
+			}
+		}
+		for _, key := range []string{"% " + kind, "% (" + kind + ")", "%" + kind, "%(" + kind + ")"} {
+			r = append(r, keyedUnit{
+				Key:  key,
+				Unit: unit,
+			})
+		}
+	}
+
+	if err := checkKeys(r); err != nil {
+		return nil, err
+	}
+
+	sort.Sort(r)
+
+	return r, nil
+}
+
+func makeNonlinearUnits() ([]keyedNonlinearUnit, error) {
+	var r []keyedNonlinearUnit
+
+	r = append(r, keyedNonlinearUnit{
+		Key: "pH",
+		Unit: &nonlinearUnit{
+			Reference: "mol/L",
+			Mapping: logMapping{
+				Base:   10,
+				Factor: -1,
+			},
+		}})
+	r = append(r, keyedNonlinearUnit{
+		Key: "pKa",
+		Unit: &nonlinearUnit{
+			Reference: "mol/L",
+			Mapping: logMapping{
+				Base:   10,
+				Factor: -1,
+			},
+		}})
+	// Power ratios
+	r = append(r, keyedNonlinearUnit{
+		Key: "dB",
+		Unit: &nonlinearUnit{
+			Mapping: logMapping{
+				Base:   10,
+				Factor: 10,
+			},
+		}})
+	r = append(r, keyedNonlinearUnit{
+		Key: "dBm",
+		Unit: &nonlinearUnit{
+			Reference: "mW",
+			Mapping: logMapping{
+				Base:   10,
+				Factor: 10,
+			},
+		}})
+	// Fold changes
+	r = append(r, keyedNonlinearUnit{
+		Key: "log10",
+		Unit: &nonlinearUnit{
+			Mapping: logMapping{
+				Base:   10,
+				Factor: 1,
+			},
+		}})
+	r = append(r, keyedNonlinearUnit{
+		Key: "log2",
+		Unit: &nonlinearUnit{
+			Mapping: logMapping{
+				Base:   2,
+				Factor: 1,
+			},
+		}})
 
 	seen := make(map[string]bool)
 	for _, v := range r {
@@ -461,8 +891,6 @@ func makeUnits() ([]keyedUnit, error) {
 		seen[v.Key] = true
 	}
 
-	sort.Sort(r)
-
 	return r, nil
 }
 
@@ -477,4 +905,14 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
+
+	defaultNonlinearUnits, err = makeNonlinearUnits()
+	if err != nil {
+		panic(err)
+	}
+
+	defaultRegistry, err = newRegistry(defaultScales, defaultUnits, defaultNonlinearUnits)
+	if err != nil {
+		panic(err)
+	}
 }
@@ -0,0 +1,157 @@
+package units
+
+import (
+	"errors"
+	"sort"
+)
+
+var (
+	errWrongKind = errors.New("wrong kind")
+)
+
+// Kinds of quantity that share a dimension with other kinds, e.g., activity
+// (Bq) and frequency (Hz) are both T^-1. There is no energy or torque kind:
+// N·m is a product of kindless units, so it would convert to J regardless.
+const (
+	frequencyKind      = "frequency"
+	angleKind          = "angle" // So that rad/s is not a frequency
+	activityKind       = "activity"
+	absorbedDoseKind   = "absorbed dose"
+	doseEquivalentKind = "dose equivalent"
+	absorbanceKind     = "absorbance"
+	stockMultipleKind  = "stock multiple"
+)
+
+// Kinds of dimensionless quantities that cannot be converted to or from
+// kindless quantities, not even plain numbers or percentages, because reading
+// one as the other is always a mistake
+var exclusiveKinds = map[string]bool{
+	absorbanceKind:    true,
+	stockMultipleKind: true,
+}
+
+// A factor of the kind of a quantity, e.g., activity^1 for Bq/ml
+type uKind struct {
+	Name string
+	Exp  uComponent
+}
+
+// Make the kinds of a unit of a single kind
+func mkkind(name string) []uKind {
+	return []uKind{
+		{Name: name, Exp: intComponent(1)},
+	}
+}
+
+// Return the product of kinds. Kinds are kept sorted by name without zero
+// exponents, so equal kinds compare equal element by element.
+func multiplyKinds(a, b []uKind) ([]uKind, error) {
+	if len(b) == 0 {
+		return a, nil
+	}
+	if len(a) == 0 {
+		return b, nil
+	}
+
+	r := append([]uKind(nil), a...)
+	for _, k := range b {
+		found := false
+		for idx := range r {
+			if r[idx].Name == k.Name {
+				e, err := r[idx].Exp.Add(k.Exp)
+				if err != nil {
+					return nil, err
+				}
+				r[idx].Exp = e
+				found = true
+				break
+			}
+		}
+		if !found {
+			r = append(r, k)
+		}
+	}
+
+	var nonZero []uKind
+	for _, k := range r {
+		if k.Exp.Sign() != 0 {
+			nonZero = append(nonZero, k)
+		}
+	}
+	sort.Slice(nonZero, func(i, j int) bool {
+		return nonZero[i].Name < nonZero[j].Name
+	})
+	return nonZero, nil
+}
+
+// Return kinds raised to an exponent
+func expKinds(a []uKind, e uComponent) ([]uKind, error) {
+	var r []uKind
+	for _, k := range a {
+		exp, err := k.Exp.Mul(e)
+		if err != nil {
+			return nil, err
+		}
+		if exp.Sign() != 0 {
+			r = append(r, uKind{Name: k.Name, Exp: exp})
+		}
+	}
+	return r, nil
+}
+
+// Return if quantities of two kinds may be converted between each other.
+// Kindless quantities, like s^-1, are compatible with any kind except the
+// exclusive ones.
+func compatibleKinds(a, b []uKind) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return !hasExclusiveKind(a) && !hasExclusiveKind(b)
+	}
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// Return if any of the kinds is exclusive
+func hasExclusiveKind(a []uKind) bool {
+	for _, k := range a {
+		if exclusiveKinds[k.Name] {
+			return true
+		}
+	}
+	return false
+}
+
+// WithoutKind returns a measurement with the same value and dimension but no
+// quantity kind, so it can be converted to units of any kind with that
+// dimension. This is the explicit way to convert between kinds, e.g.,
+//
+//   New("Sv", Must(WithoutKind(dose)))
+//
+// for a dose in Gy with a radiation weighting factor of one. The kind is only
+// removed from the returned measurement: reparsing its unit string restores
+// the kind.
+func WithoutKind(mm Measurement) (Measurement, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return zeroValue, err
+	}
+	if len(m.unit.Kinds) == 0 {
+		return m, nil
+	}
+	if m.unit.Nonlinear != nil {
+		return zeroValue, errNonlinear
+	}
+	unit := *m.unit
+	unit.Kinds = nil
+	return &measure{
+		Value: m.Value,
+		Unit:  m.Unit,
+		unit:  &unit,
+	}, nil
+}
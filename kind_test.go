@@ -0,0 +1,123 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestKind(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		From       Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:       "Bq",
+			From:       Must(Parse(1.0, "Hz")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "Bq",
+			From:       Must(Parse(60.0, "rpm")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:     "Hz",
+			From:     Must(Parse(60.0, "rpm")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:       "Sv",
+			From:       Must(Parse(1.0, "Gy")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "mSv/h",
+			From:       Must(Parse(1.0, "µGy/s")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "Hz/ml",
+			From:       Must(Parse(1.0, "kBq/ml")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:     "kBq",
+			From:     Must(Parse(1.0, "MBq")),
+			Expected: 1000.0,
+		},
+		testCase{
+			Unit:     "Bq",
+			From:     Must(Parse(60.0, "min^-1")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "s^-1",
+			From:     Must(Parse(1.0, "kHz")),
+			Expected: 1000.0,
+		},
+		testCase{
+			Unit:     "Gy",
+			From:     Must(Parse(1.0, "J/kg")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "",
+			From:     Must(Parse(1.0, "Bq/Bq")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "Sv",
+			From:     Must(WithoutKind(Must(Parse(2.0, "Gy")))),
+			Expected: 2.0,
+		},
+		testCase{
+			Unit:     "Hz",
+			From:     Must(WithoutKind(Must(Parse(3.0, "kBq")))),
+			Expected: 3000.0,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if tc.ShouldFail {
+			if err != errWrongKind {
+				t.Errorf("%v %s to %q: expecting %v got %v, %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, errWrongKind, m, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v %s to %q: %s", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("%v %s to %q: expecting %v found %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+
+	// Kinds carry through products
+	activity := Must(Parse(2.0, "MBq/ml"))
+	volume := Must(Parse(0.5, "ml"))
+	if m, err := New("MBq", activity, volume); err != nil {
+		t.Error(err)
+	} else if e, f := 1.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := New("MHz", activity, volume); err != errWrongKind {
+		t.Errorf("expecting %v got %v, %v", errWrongKind, m, err)
+	}
+
+	if c, err := NewConverter("Gy", "Sv"); err != errWrongKind {
+		t.Errorf("expecting %v got %v, %v", errWrongKind, c, err)
+	}
+	if _, err := NewConverter("Gy", "J/kg"); err != nil {
+		t.Error(err)
+	}
+
+	// Energy and torque are not distinguished
+	if m, err := New("J", Must(Parse(2.0, "N·m"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
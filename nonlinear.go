@@ -0,0 +1,94 @@
+package units
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	errNonlinear   = errors.New("arithmetic on nonlinear unit")
+	errNonPositive = errors.New("logarithm of non-positive value")
+)
+
+// A nonlinear unit is one whose values are not proportional to quantities of
+// a linear reference unit, e.g., pH and mol/L or °F and °C. Nonlinear units
+// cannot be combined with other units.
+type nonlinearUnit struct {
+	Reference string // Reference unit
+	Mapping   nonlinearMapping
+	ref       *pUnit // Parsed reference unit
+}
+
+// A mapping between values of a nonlinear unit and quantities of its
+// reference unit
+type nonlinearMapping interface {
+	// Return the quantity in the reference unit of a value
+	Linear(v float64) (float64, error)
+	// Return the value of a quantity in the reference unit
+	Nonlinear(x float64) (float64, error)
+}
+
+// Return the linear form of a value
+func (a *nonlinearUnit) Linear(v float64) (*measure, error) {
+	x, err := a.Mapping.Linear(v)
+	if err != nil {
+		return nil, err
+	}
+	return &measure{
+		Value: x,
+		Unit:  a.Reference,
+		unit:  a.ref,
+	}, nil
+}
+
+// Return the value of a quantity in the reference unit
+func (a *nonlinearUnit) Nonlinear(x float64) (float64, error) {
+	return a.Mapping.Nonlinear(x)
+}
+
+// A logarithmic mapping. A value v corresponds to the linear quantity
+// Base^(v/Factor), e.g., pH 7 is 10^(7/-1) mol/L.
+type logMapping struct {
+	Base   float64 // Base of the logarithm
+	Factor float64 // Multiplier of the logarithm
+}
+
+func (a logMapping) Linear(v float64) (float64, error) {
+	x := math.Pow(a.Base, v/a.Factor)
+	if x == 0.0 {
+		return 0.0, errUnderflow
+	}
+	if math.IsInf(x, 0) {
+		return 0.0, errOverflow
+	}
+	return x, nil
+}
+
+func (a logMapping) Nonlinear(x float64) (float64, error) {
+	if !(x > 0.0) {
+		return 0.0, errNonPositive
+	}
+	// Log10 and Log2 are exact at powers of their base
+	switch a.Base {
+	case 10:
+		return a.Factor * math.Log10(x), nil
+	case 2:
+		return a.Factor * math.Log2(x), nil
+	}
+	return a.Factor * math.Log(x) / math.Log(a.Base), nil
+}
+
+// An affine mapping. A value v corresponds to the linear quantity
+// Factor·(v+Offset), e.g., 212 °F is 5/9·(212-32) °C.
+type affineMapping struct {
+	Factor float64
+	Offset float64
+}
+
+func (a affineMapping) Linear(v float64) (float64, error) {
+	return a.Factor * (v + a.Offset), nil
+}
+
+func (a affineMapping) Nonlinear(x float64) (float64, error) {
+	return x/a.Factor - a.Offset, nil
+}
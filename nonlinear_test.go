@@ -0,0 +1,149 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLogarithmic(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		From     Measurement
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "mol/L",
+			From:     Must(Parse(7.0, "pH")),
+			Expected: 1e-7,
+		},
+		testCase{
+			Unit:     "pH",
+			From:     Must(Parse(1.0, "mmol/L")),
+			Expected: 3.0,
+		},
+		testCase{
+			Unit:     "pKa",
+			From:     Must(Parse(4.76, "pH")),
+			Expected: 4.76,
+		},
+		testCase{
+			Unit:     "",
+			From:     Must(Parse(20.0, "dB")),
+			Expected: 100.0,
+		},
+		testCase{
+			Unit:     "dB",
+			From:     Must(Parse(2.0, "log10")),
+			Expected: 20.0,
+		},
+		testCase{
+			Unit:     "W",
+			From:     Must(Parse(30.0, "dBm")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "dBm",
+			From:     Must(Parse(1.0, "mW")),
+			Expected: 0.0,
+		},
+		testCase{
+			Unit:     "log2",
+			From:     Must(Parse(8.0, "")),
+			Expected: 3.0,
+		},
+		testCase{
+			Unit:     "",
+			From:     Must(Parse(-1.0, "log2")),
+			Expected: 0.5,
+		},
+		testCase{
+			Unit:     "mol/L",
+			From:     Must(Parse(0.0, "pH")),
+			Expected: 1.0,
+		},
+		testCase{
+			Unit:     "dBm",
+			From:     Must(Parse(1.0, "W")),
+			Expected: 30.0,
+		},
+		testCase{
+			Unit:     "pH",
+			From:     Must(Parse(1.0, "nmol/L")),
+			Expected: 9.0,
+		},
+		testCase{
+			Unit:     "log2",
+			From:     Must(Parse(1.0/1024, "")),
+			Expected: -10.0,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if err != nil {
+			t.Errorf("%v %s to %q: %s", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("%v %s to %q: expecting %v found %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+}
+
+func TestAffine(t *testing.T) {
+	r, err := NewRegistry(USCustomary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range []float64{-40.0, 0.0, 32.0, 98.6} {
+		c, err := r.New("°C", Must(r.Parse(v, "°F")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e, f := (v-32.0)*5.0/9.0, c.Quantity(); math.Abs(e-f) > 1e-12 {
+			t.Errorf("%v °F: expecting %v found %v", v, e, f)
+		}
+		if f, err := r.New("°F", c); err != nil {
+			t.Error(err)
+		} else if math.Abs(v-f.Quantity()) > 1e-12 {
+			t.Errorf("expecting %v found %v", v, f.Quantity())
+		}
+	}
+
+	// °F is not a temperature difference, so it does not scale
+	if m, err := r.Parse(1.0, "°F/s"); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := r.New("K", Must(r.Parse(32.0, "°F"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+}
+
+func TestLogarithmicArithmetic(t *testing.T) {
+	pH := Must(Parse(7.0, "pH"))
+
+	if m, err := New("pH", pH, pH); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := New("mol/L", Must(Parse(1.0, "")), pH); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := Reciprocal(pH); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := New("pH", Must(Parse(0.0, "mol/L"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if m, err := New("pH", Must(Parse(1.0, "g/L"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+	if c, err := NewConverter("pH", "mol/L"); err == nil {
+		t.Errorf("expecting error got %v", c)
+	}
+	for _, u := range []string{"pKa/s", "log2^2", "dBm^2", "kpKa"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package units
+
+import (
+	"errors"
+	"strconv"
+	"unicode"
+)
+
+// Avogadro constant in mol^-1
+const avogadro = 6.02214076e23
+
+var (
+	errNoLength = errors.New("no length or sequence")
+)
+
+// NucleicAcidType is the kind of a nucleic acid
+type NucleicAcidType int
+
+// Kinds of nucleic acids
+const (
+	DNA NucleicAcidType = iota
+	RNA
+)
+
+// Molar masses in g/mol. Residue masses are of nucleoside monophosphates less
+// water; average pair masses are per base pair of both strands; end masses are
+// the additional mass of each strand: water for DNA and the 5' triphosphate for
+// RNA.
+var (
+	dnaResidueMass = map[rune]float64{
+		'A': 313.21,
+		'C': 289.18,
+		'G': 329.21,
+		'T': 304.2,
+	}
+	rnaResidueMass = map[rune]float64{
+		'A': 329.21,
+		'C': 305.18,
+		'G': 345.21,
+		'U': 306.17,
+	}
+	dnaComplement = map[rune]rune{
+		'A': 'T',
+		'C': 'G',
+		'G': 'C',
+		'T': 'A',
+	}
+	rnaComplement = map[rune]rune{
+		'A': 'U',
+		'C': 'G',
+		'G': 'C',
+		'U': 'A',
+	}
+)
+
+const (
+	dnaAverageResidueMass = 308.97
+	dnaAveragePairMass    = 617.96
+	dnaEndMass            = 18.02
+	rnaAverageResidueMass = 320.5
+	rnaAveragePairMass    = 641.0
+	rnaEndMass            = 159.0
+)
+
+// A NucleicAcid describes a DNA or RNA molecule for converting between mass,
+// amount of substance and copy number.
+//
+// The molar mass is estimated from Length using average residue masses, or
+// computed exactly from Sequence if it is given. Double stranded molecules
+// count both strands, so Length is in base pairs and the sequence is that of
+// one strand.
+type NucleicAcid struct {
+	Type           NucleicAcidType
+	DoubleStranded bool
+	Length         Measurement // Length in bp or nt
+	Sequence       string      // Optional sequence of one strand
+}
+
+// MolarMass returns the molar mass of the nucleic acid in g/mol.
+func (a NucleicAcid) MolarMass() (Measurement, error) {
+	residueMass, complement := dnaResidueMass, dnaComplement
+	averageMass, endMass := dnaAverageResidueMass, dnaEndMass
+	if a.DoubleStranded {
+		averageMass = dnaAveragePairMass
+	}
+	if a.Type == RNA {
+		residueMass, complement = rnaResidueMass, rnaComplement
+		averageMass, endMass = rnaAverageResidueMass, rnaEndMass
+		if a.DoubleStranded {
+			averageMass = rnaAveragePairMass
+		}
+	}
+
+	strands := 1.0
+	if a.DoubleStranded {
+		strands = 2.0
+	}
+
+	var mass float64
+	if len(a.Sequence) != 0 {
+		for _, r := range a.Sequence {
+			r = unicode.ToUpper(r)
+			m, ok := residueMass[r]
+			if !ok {
+				return zeroValue, errors.New("invalid residue " + strconv.QuoteRune(r))
+			}
+			mass += m
+			if a.DoubleStranded {
+				mass += residueMass[complement[r]]
+			}
+		}
+	} else if a.Length != nil {
+		length, err := New("nt", a.Length)
+		if err != nil {
+			return zeroValue, err
+		}
+		mass = length.Quantity() * averageMass
+	} else {
+		return zeroValue, errNoLength
+	}
+	mass += endMass * strands
+
+	return Parse(mass, "g/mol")
+}
+
+// Amount converts a mass or copy number of the nucleic acid to an amount of
+// substance in mol.
+func (a NucleicAcid) Amount(m Measurement) (Measurement, error) {
+	return a.convert("mol", m)
+}
+
+// Mass converts an amount of substance or copy number of the nucleic acid to a
+// mass in g.
+func (a NucleicAcid) Mass(m Measurement) (Measurement, error) {
+	return a.convert("g", m)
+}
+
+// Copies converts a mass or amount of substance of the nucleic acid to a copy
+// number.
+func (a NucleicAcid) Copies(m Measurement) (Measurement, error) {
+	return a.convert("copies", m)
+}
+
+// Convert a mass, amount or copy number to unitString by way of the amount of
+// substance
+func (a NucleicAcid) convert(unitString string, m Measurement) (Measurement, error) {
+	mm, err := parse(m)
+	if err != nil {
+		return zeroValue, err
+	}
+	molarMass, err := a.MolarMass()
+	if err != nil {
+		return zeroValue, err
+	}
+	perMole := Must(Parse(avogadro, "copies/mol"))
+
+	var amount Measurement
+	switch {
+	case hasDimension(mm, "mol"):
+		amount = mm
+	case hasDimension(mm, "g"):
+		amount, err = New("mol", mm, Must(Reciprocal(molarMass)))
+	case hasDimension(mm, "copies"):
+		amount, err = New("mol", mm, Must(Reciprocal(perMole)))
+	default:
+		return zeroValue, errWrongDimension
+	}
+	if err != nil {
+		return zeroValue, err
+	}
+
+	switch unitString {
+	case "g":
+		return New(unitString, amount, molarMass)
+	case "copies":
+		return New(unitString, amount, perMole)
+	default:
+		return New(unitString, amount)
+	}
+}
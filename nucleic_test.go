@@ -0,0 +1,123 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNucleicAcidMolarMass(t *testing.T) {
+	type testCase struct {
+		Acid       NucleicAcid
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Acid: NucleicAcid{
+				DoubleStranded: true,
+				Length:         Must(Parse(3.0, "kbp")),
+			},
+			Expected: 3000*617.96 + 36.04,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Length: Must(Parse(20.0, "nt")),
+			},
+			Expected: 20*308.97 + 18.02,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Type:   RNA,
+				Length: Must(Parse(100.0, "nt")),
+			},
+			Expected: 100*320.5 + 159.0,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Sequence: "acgt",
+			},
+			Expected: 313.21 + 289.18 + 329.21 + 304.2 + 18.02,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				DoubleStranded: true,
+				Sequence:       "AAC",
+			},
+			Expected: 2*313.21 + 289.18 + 2*304.2 + 329.21 + 2*18.02,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Type:     RNA,
+				Sequence: "ACGU",
+			},
+			Expected: 329.21 + 305.18 + 345.21 + 306.17 + 159.0,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Sequence: "ACGU",
+			},
+			ShouldFail: true,
+		},
+		testCase{
+			Acid: NucleicAcid{
+				Length: Must(Parse(20.0, "g")),
+			},
+			ShouldFail: true,
+		},
+		testCase{
+			Acid:       NucleicAcid{},
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := tc.Acid.MolarMass()
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("%+v: expecting error got %v", tc.Acid, m)
+			}
+		} else if err != nil {
+			t.Errorf("%+v: %s", tc.Acid, err)
+		} else if e, f := "g/mol", m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-9*e {
+			t.Errorf("%+v: expecting %v found %v", tc.Acid, e, f)
+		}
+	}
+}
+
+func TestNucleicAcidConversions(t *testing.T) {
+	plasmid := NucleicAcid{
+		DoubleStranded: true,
+		Length:         Must(Parse(3.0, "kbp")),
+	}
+
+	// 500 ng of a 3 kb plasmid is about 270 fmol
+	amount, err := plasmid.Amount(Must(Parse(500.0, "ng")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmol := Must(New("fmol", amount))
+	if e, f := 500e-9/(3000*617.96+36.04)*1e15, fmol.Quantity(); math.Abs(e-f) > 1e-9*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	copies, err := plasmid.Copies(fmol)
+	if err != nil {
+		t.Fatal(err)
+	} else if e, f := amount.Quantity()*avogadro, copies.Quantity(); math.Abs(e-f) > 1e-9*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	mass, err := plasmid.Mass(copies)
+	if err != nil {
+		t.Fatal(err)
+	} else if e, f := 500e-9, mass.Quantity(); math.Abs(e-f) > 1e-9*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if m, err := plasmid.Amount(Must(Parse(1.0, "ml"))); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+}
@@ -3,6 +3,7 @@ package units
 import (
 	"bytes"
 	"errors"
+	"math"
 	"strconv"
 	"strings"
 	"unicode"
@@ -11,7 +12,6 @@ import (
 
 var (
 	errSymbolNotFound = errors.New("symbol not found")
-	errPrefixNotFound = errors.New("prefix not found")
 	errUnparsedText   = errors.New("unparsed text")
 )
 
@@ -23,32 +23,57 @@ func makeParseError(data []byte, pos int, err error) error {
 		err.Error())
 }
 
-func makeRuneNotFoundError(r rune) error {
-	return errors.New(strconv.QuoteRune(r) + " not found")
+// Error for an expected rune. Parsing probes for optional runes at most
+// positions, so this is a value type rather than a formatted string to keep
+// failed probes cheap.
+type runeNotFoundError rune
+
+func (a runeNotFoundError) Error() string {
+	return strconv.QuoteRune(rune(a)) + " not found"
 }
 
 // Parse a quantity and unit into a measurement.
 //
 // Unit Grammar:
 //   ValidUnit := Unit
+//              | Nonlinear
 //              | ""    # Dimensionless measurement
 //   Unit      := Term
-//              | ( Unit )        # Grouping
-//              | Unit  ^ Integer # Unit exponentiation
-//              | Unit  /  Unit   # Unit division
-//              | Unit  ·  Unit   # Unit multiplication (· is center dot)
-//              | Unit " " Unit   # Unit multiplication (" " is whitespace)
+//              | ( Unit )         # Grouping
+//              | Unit  ^ Exponent # Unit exponentiation
+//              | Unit  /  Unit    # Unit division
+//              | Unit  ·  Unit    # Unit multiplication (· is center dot)
+//              | Unit " " Unit    # Unit multiplication (" " is whitespace)
 //   Term      := Prefix? Symbol
 //   #            1    2   3   6   9  12  15  18  21  24  # Exp
 //   Prefix    := da | h | k | M | G | T | P | E | Z | Y  # 10^Exp
 //              | d  | c | m | μ | n | p | f | a | z | y  # 10^-Exp
 //              |              u
+//              |              µ
+//              | Ki | Mi | Gi | Ti | Pi | Ei | Zi | Yi # 2^(10·n), bit and B only
 //   Symbol    := m   | g  | s  | A | K  | mol | cd  # Base dimensions
 //              | rad | st | Hz | N | Pa | J         # Derived units
 //              | W   | C  | V  | F | Ω  | S
 //              | Wb  | T  | H  | °C | ℃
 //              | lm  | lx | Bq | Gy | Sv | kat
-//              | l   | L  | Da                      # Non-SI units
+//              | l   | L  | Da | M                  # Non-SI units
+//              | min | h
+//              | bit | B                           # Information
+//              | deg | °  | arcmin | ′ | arcsec | ″  # Angles
+//              | rev | gon
+//              | bp  | nt | cells | CFU | copies     # Counts
+//              | U   | IU                           # Enzyme activity
+//              | rpm | ×g | xg                      # Centrifugation
+//              | %   | ppm | ppb                   # Ratios
+//              | X                                # Stock multiple
+//              | AU  | OD                          # Absorbance
+//              | % w/w | % v/v | % w/v             # Percent solutions
+//   Nonlinear := pH | pKa                          # -log10 of mol/L
+//              | dB  | dBm                         # 10·log10 of ratio, mW
+//              | log10 | log2                      # Fold changes
+//   Exponent  := Integer
+//              | Integer . Digits                # E.g., 0.5 or -1.5
+//              | ( Integer / Integer )           # E.g., (1/2) or (-3/2)
 //   Integer   := ..., -2, -1, 0, 1, 2, ...
 //
 // Examples:
@@ -62,43 +87,117 @@ func makeRuneNotFoundError(r rune) error {
 //   library may or may not accept such ambigious units. For portability, users
 //   should parenthesize or convert division to exponentiation.
 //   - C is Coulomb; °C or ℃ is degree Celsius
+//   - μ (Greek mu, U+03BC), µ (micro sign, U+00B5) and u are all micro
+//   - h alone is hour; hm is hectometre
+//   - bit and B only take prefixes of k and above, so dB is decibel, not
+//   decibyte
+//   - M is molar (mol/L); MM is megamolar and Mm is megametre
+//   - rpm is a frequency (1/60 Hz); rev/min is an angular velocity (2π/60
+//   rad/s). The two cannot be converted into each other.
+//   - Nonlinear units cannot be combined with other units, and nonlinear
+//   measurements can only be converted with New, not multiplied with other
+//   measurements. A unit string consisting of just pH is pH, not picohenry.
+//   - Percent solutions may also be written as % (w/v), %w/v or %(w/v). Mass
+//   (w/w) and volume (v/v) fractions cannot be converted between each other;
+//   w/v is grams per 100 ml.
+//   - Dimension exponents, including those of partial products, must be
+//   between MinExponent and MaxExponent with denominators of at most
+//   MaxExponentDenominator; an *ExponentError is returned otherwise. A zero
+//   denominator, as in m^(1/0), is a divide by zero error.
+//   - US customary and imperial units (in, ft, yd, mi, oz, lb, gal, fl oz,
+//   imp gal, °F, ...) are only available from a Registry created with
+//   NewRegistry. They take no prefixes.
 func Parse(quantity float64, unitString string) (Measurement, error) {
+	return defaultRegistry.Parse(quantity, unitString)
+}
+
+// Parse is like the package-level Parse but also accepts the optional units
+// of the registry.
+func (r *Registry) Parse(quantity float64, unitString string) (Measurement, error) {
+	unit, err := r.lookup(unitString)
+	if err != nil {
+		return zeroValue, err
+	}
+
+	return &measure{
+		Value: quantity,
+		Unit:  unitString,
+		unit:  unit,
+	}, nil
+}
+
+// Return the parsed unit for a unit string in the default registry
+func lookupUnit(unitString string) (*pUnit, error) {
+	return defaultRegistry.lookup(unitString)
+}
+
+// Return the parsed unit for a unit string, parsing it at most once. Parsing
+// allocates for each term and intermediate result, but cache hits do not
+// allocate.
+func (r *Registry) lookup(unitString string) (*pUnit, error) {
+	if v, ok := r.cache.Load(unitString); ok {
+		return v.(*pUnit), nil
+	}
+	// Hold the lock until the result is cached, so that DefineDimension
+	// cannot clear the cache in between
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	unit, err := r.parseUnitString(unitString)
+	if err != nil {
+		return nil, err
+	}
+	v, _ := r.cache.LoadOrStore(unitString, unit)
+	return v.(*pUnit), nil
+}
+
+// Parse a unit string without consulting the cache. The caller must hold
+// r.mu unless r is still being constructed.
+func (r *Registry) parseUnitString(unitString string) (*pUnit, error) {
 	data := []byte(unitString)
 
 	if len(data) == 0 {
-		return zeroValue, nil
+		return &pUnit{}, nil
 	}
 
-	unit, pos, err := parseUnit(data, 0)
-	if err != nil {
-		return zeroValue, makeParseError(data, pos, err)
+	// NonlinearUnit
+	key := strings.TrimSpace(unitString)
+	if nu, ok := r.nonlinearUnits[key]; ok {
+		return &pUnit{
+			Terms: []uTerm{
+				{Symbol: key, Exp: intComponent(1)},
+			},
+			Nonlinear: nu,
+		}, nil
+	}
+
+	unit, pos, err := r.parseUnit(data, 0)
+	if isExponentError(err) {
+		return nil, err
+	} else if err != nil {
+		return nil, makeParseError(data, pos, err)
 	}
 	pos, _ = scanToNonSpace(data, pos, false)
 	if pos != len(data) {
-		return zeroValue, makeParseError(data, pos, errUnparsedText)
+		return nil, makeParseError(data, pos, errUnparsedText)
 	}
 
-	return &measure{
-		Value: quantity,
-		Unit:  unitString,
-		unit:  unit,
-	}, nil
+	return unit, nil
 }
 
-func parseUnit(data []byte, pos int) (*pUnit, int, error) {
+func (r *Registry) parseUnit(data []byte, pos int) (*pUnit, int, error) {
 	var unit *pUnit
 	pos, _ = scanToNonSpace(data, pos, false)
 
 	// Unit := ( Unit ) | Term
 	pos, err := parseRune(data, pos, '(')
 	if err == nil {
-		if unit, pos, err = parseUnit(data, pos); err != nil {
+		if unit, pos, err = r.parseUnit(data, pos); err != nil {
 			return nil, pos, err
 		} else if pos, err = parseRune(data, pos, ')'); err != nil {
 			return nil, pos, err
 		}
 	} else {
-		unit, pos, err = parseTerm(data, pos)
+		unit, pos, err = r.parseTerm(data, pos)
 		if err != nil {
 			return nil, pos, err
 		}
@@ -109,32 +208,42 @@ func parseUnit(data []byte, pos int) (*pUnit, int, error) {
 
 	pos, hadSpace := scanToNonSpace(data, pos, false)
 
-	// Unit := Unit ^ Integer
+	// Unit := Unit ^ Exponent
 	if exp, pos, err = parseExponent(data, pos); err == nil {
-		unit = unit.Exp(exp)
+		if unit, err = unit.Exp(exp); err != nil {
+			return nil, pos, err
+		}
 		pos, hadSpace = scanToNonSpace(data, pos, false)
+	} else if isExponentError(err) {
+		return nil, pos, err
 	}
 
 	// Unit := ...
 	if pos, err = parseRune(data, pos, '/'); err == nil {
 		// ... | Unit / Unit
-		nextUnit, pos, err = parseUnit(data, pos)
+		nextUnit, pos, err = r.parseUnit(data, pos)
 		if err != nil {
 			return nil, pos, err
 		}
-		unit = unit.Multiply(nextUnit.Reciprocal())
+		if unit, err = unit.Multiply(nextUnit.Reciprocal()); err != nil {
+			return nil, pos, err
+		}
 	} else if pos, err = parseRune(data, pos, '·'); err == nil {
 		// ... |  Unit · Unit
-		nextUnit, pos, err = parseUnit(data, pos)
+		nextUnit, pos, err = r.parseUnit(data, pos)
 		if err != nil {
 			return nil, pos, err
 		}
-		unit = unit.Multiply(nextUnit)
+		if unit, err = unit.Multiply(nextUnit); err != nil {
+			return nil, pos, err
+		}
 	} else if hadSpace {
 		// ... | Unit " " Unit
-		nextUnit, pos, err = parseUnit(data, pos)
+		nextUnit, pos, err = r.parseUnit(data, pos)
 		if err == nil {
-			unit = unit.Multiply(nextUnit)
+			if unit, err = unit.Multiply(nextUnit); err != nil {
+				return nil, pos, err
+			}
 		}
 	}
 
@@ -144,81 +253,162 @@ func parseUnit(data []byte, pos int) (*pUnit, int, error) {
 
 func parseRune(data []byte, pos int, r rune) (int, error) {
 	if len(data) <= pos {
-		return pos, makeRuneNotFoundError(r)
+		return pos, runeNotFoundError(r)
 	}
 	dr, width := utf8.DecodeRune(data[pos:])
 	if dr != r {
-		return pos, makeRuneNotFoundError(r)
+		return pos, runeNotFoundError(r)
 	}
 	return pos + width, nil
 }
 
-func parseTerm(data []byte, startPos int) (*pUnit, int, error) {
-	// Term := Prefix?
-	scale, pos, _ := parsePrefix(data, startPos)
-	//   ... Symbol
-	unit, pos, err := parseSymbol(data, pos)
+func (r *Registry) parseTerm(data []byte, startPos int) (*pUnit, int, error) {
+	// Term := Symbol
+	ku, pos, err := r.parseSymbol(data, startPos)
+	prefix := -1
+
+	// Term := Prefix Symbol
+	//
+	// Some symbols are also prefixes (e.g., m(illi) and m(eter)), so take
+	// whichever reading consumes the most input, preferring the unprefixed
+	// symbol on a tie.
+	var buf [4]trieMatch
+	for _, pm := range r.scaleTrie.matches(data, startPos, buf[:0]) {
+		u, p, e := r.parseSymbol(data, pm.End)
+		if e != nil || u.NoPrefix || (err == nil && p <= pos) {
+			continue
+		}
+		if ks := r.scales[pm.Value]; ks.Factor != 0 && !u.BinaryPrefix {
+			continue
+		} else if ks.Factor == 0 && ks.Scale < 3 && u.LargePrefix {
+			continue
+		}
+		ku, pos, err = u, p, nil
+		prefix = pm.Value
+	}
 	if err != nil {
-		// Some symbols are substrings of prefixes (e.g., m(illi) and m(eter)),
-		// so try Term := Symbol as well
-		unit, pos, err = parseSymbol(data, startPos)
-		if err != nil {
-			return nil, pos, err
+		return nil, pos, err
+	}
+
+	unit := newUnit(1)
+	unit.Dim = ku.Unit.Dim
+	unit.DimLess = ku.Unit.DimLess
+	unit.Scale = ku.Unit.Scale
+	unit.Factor = ku.Unit.Factor
+	unit.Kinds = ku.Unit.Kinds
+	unit.Terms = append(unit.Terms, uTerm{Symbol: ku.Key, Exp: intComponent(1)})
+	if prefix >= 0 {
+		ks := r.scales[prefix]
+		unit.Scale += ks.Scale
+		if ks.Factor != 0 {
+			unit.Factor = unit.factor() * ks.Factor
 		}
-		scale = 0
+		unit.Terms[0].Prefix = ks.Key
 	}
-	return &pUnit{
-		Dim:   unit.Dim,
-		Scale: scale,
-	}, pos, nil
+	return unit, pos, nil
 }
 
-func parsePrefix(data []byte, pos int) (int, int, error) {
+func (r *Registry) parseSymbol(data []byte, pos int) (keyedUnit, int, error) {
 	if len(data) <= pos {
-		return 0, pos, errPrefixNotFound
+		return keyedUnit{}, pos, errSymbolNotFound
 	}
 
-	str := string(data[pos:])
-
-	for _, ks := range defaultScales {
-		if strings.HasPrefix(str, ks.Key) {
-			return ks.Scale, pos + len(ks.Key), nil
-		}
+	m, ok := r.unitTrie.longest(data, pos)
+	if !ok {
+		return keyedUnit{}, pos, errSymbolNotFound
 	}
-
-	return 0, pos, errPrefixNotFound
+	return r.units[m.Value], m.End, nil
 }
 
-func parseSymbol(data []byte, pos int) (*pUnit, int, error) {
-	if len(data) <= pos {
-		return nil, pos, errPrefixNotFound
+// Parse an exponent. On failure, the returned position is that of the ^ so
+// that the caller sees the rest of the input as unparsed.
+func parseExponent(data []byte, start int) (uComponent, int, error) {
+	pos, err := parseRune(data, start, '^')
+	if err != nil {
+		return intComponent(1), start, err
 	}
 
-	str := string(data[pos:])
-	for _, ku := range defaultUnits {
-		if strings.HasPrefix(str, ku.Key) {
-			return ku.Unit, pos + len(ku.Key), nil
+	// Exponent := ( Integer / Integer )
+	if p, err := parseRune(data, pos, '('); err == nil {
+		num, p, err := parseInteger(data, p)
+		if err != nil {
+			return intComponent(1), start, err
+		}
+		if p, err = parseRune(data, p, '/'); err != nil {
+			return intComponent(1), start, err
 		}
+		den, p, err := parseInteger(data, p)
+		if err != nil {
+			return intComponent(1), start, err
+		}
+		if p, err = parseRune(data, p, ')'); err != nil {
+			return intComponent(1), start, err
+		}
+		if den == 0 {
+			return intComponent(1), start, errDivideByZero
+		}
+		e, err := checkedComponent(num, den)
+		if err != nil {
+			return intComponent(1), start, err
+		}
+		return e, p, nil
 	}
 
-	return nil, pos, errSymbolNotFound
-}
-
-func parseExponent(data []byte, pos int) (uComponent, int, error) {
-	pos, err := parseRune(data, pos, '^')
+	// Exponent := Integer | Integer . Digits
+	num, end, err := parseInteger(data, pos)
+	if err != nil {
+		return intComponent(1), start, err
+	}
+	den := 1
+	if p, err := parseRune(data, end, '.'); err == nil {
+		sign := 1
+		if data[pos] == '-' {
+			sign = -1
+		}
+		for end = p; end < len(data) && '0' <= data[end] && data[end] <= '9'; end++ {
+			num = 10*num + sign*int(data[end]-'0')
+			den *= 10
+			// No exponent with more decimal places is representable
+			if den > 1e6 {
+				g := gcd(num, den)
+				return intComponent(1), start, &ExponentError{Num: num / g, Den: den / g}
+			}
+		}
+		if end == p {
+			return intComponent(1), start, errUnparsedText
+		}
+	}
+	e, err := checkedComponent(num, den)
 	if err != nil {
-		return 1, pos, err
+		return intComponent(1), start, err
 	}
+	return e, end, nil
+}
 
+// Return if an error is from a well-formed but invalid exponent, like m^200
+// or m^(1/0), rather than from text that is not an exponent
+func isExponentError(err error) bool {
+	_, ok := err.(*ExponentError)
+	return ok || err == errDivideByZero
+}
+
+// Parse an optionally signed integer. Integers too large for any exponent
+// return an *ExponentError.
+func parseInteger(data []byte, pos int) (int, int, error) {
 	// Scan to end of integer string
 	end := scanToNonDigit(data, pos)
 
 	// Parse
-	i, err := strconv.ParseInt(string(data[pos:end]), 10, 8)
-	if err != nil {
-		return 1, pos, err
+	i, err := strconv.ParseInt(string(data[pos:end]), 10, 64)
+	if ne, ok := err.(*strconv.NumError); ok && ne.Err == strconv.ErrRange {
+		return 0, pos, &ExponentError{Num: int(i), Den: 1}
+	} else if err != nil {
+		return 0, pos, err
 	}
-	return uComponent(i), end, nil
+	if i < math.MinInt32 || i > math.MaxInt32 {
+		return 0, pos, &ExponentError{Num: int(i), Den: 1}
+	}
+	return int(i), end, nil
 }
 
 // Return position of first non-space or len(data) if none and if whitespace
@@ -253,13 +443,24 @@ func scanToNonDigit(data []byte, pos int) int {
 	return idx
 }
 
+// Return the parsed form of a measurement. The result may be m itself, so it
+// must not be modified.
 func parse(m Measurement) (*measure, error) {
+	return defaultRegistry.parse(m)
+}
+
+// Return the parsed form of a measurement using the registry's units
+func (r *Registry) parse(m Measurement) (*measure, error) {
 	if m, ok := m.(*measure); ok {
 		return m, nil
 	}
-	m, err := Parse(m.Quantity(), m.MeasurementUnit())
+	unit, err := r.lookup(m.MeasurementUnit())
 	if err != nil {
 		return nil, err
 	}
-	return m.(*measure), nil
+	return &measure{
+		Value: m.Quantity(),
+		Unit:  m.MeasurementUnit(),
+		unit:  unit,
+	}, nil
 }
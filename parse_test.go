@@ -18,6 +18,13 @@ func TestParseDimensions(t *testing.T) {
 	}
 
 	um := makeUnitMap()
+	mul := func(a, b *pUnit) *pUnit {
+		u, err := a.Multiply(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return u
+	}
 
 	suite := []testCase{
 		testCase{
@@ -50,7 +57,7 @@ func TestParseDimensions(t *testing.T) {
 		},
 		testCase{
 			Unit:     "°C^2 °C",
-			Expected: um["°C"].Exp(3).product(),
+			Expected: mkpoint(de{temperatureCDim: 3}),
 		},
 		testCase{
 			Unit:     "(m)",
@@ -70,15 +77,15 @@ func TestParseDimensions(t *testing.T) {
 		},
 		testCase{
 			Unit:     "kmol / s",
-			Expected: um["mol"].Multiply(um["s"].Reciprocal()).product(),
+			Expected: mul(um["mol"], um["s"].Reciprocal()).product(),
 		},
 		testCase{
 			Unit:     "g/L",
-			Expected: um["g"].Multiply(um["L"].Reciprocal()).product(),
+			Expected: mul(um["g"], um["L"].Reciprocal()).product(),
 		},
 		testCase{
 			Unit:     "ug/uL",
-			Expected: um["g"].Multiply(um["L"].Reciprocal()).product(),
+			Expected: mul(um["g"], um["L"].Reciprocal()).product(),
 		},
 		testCase{
 			Unit:     "s^-1",
@@ -90,16 +97,28 @@ func TestParseDimensions(t *testing.T) {
 		},
 		testCase{
 			Unit:     "kg·m/(s^2 s)",
-			Expected: um["N"].Multiply(um["Hz"]).product(),
+			Expected: mul(um["N"], um["Hz"]).product(),
 		},
 		testCase{
 			Unit:     "(kg·m/(s^2 s))^-1",
-			Expected: um["N"].Multiply(um["Hz"]).Reciprocal().product(),
+			Expected: mul(um["N"], um["Hz"]).Reciprocal().product(),
 		},
 		testCase{
 			Unit:     "N/m^2",
 			Expected: um["Pa"].product(),
 		},
+		testCase{
+			Unit:     "dam",
+			Expected: um["m"].product(),
+		},
+		testCase{
+			Unit:     "mmol",
+			Expected: um["mol"].product(),
+		},
+		testCase{
+			Unit:     "cd",
+			Expected: um["cd"].product(),
+		},
 		testCase{
 			Unit:       "(m",
 			ShouldFail: true,
@@ -127,3 +146,181 @@ func TestParseDimensions(t *testing.T) {
 		//t.Logf("%q is %q\n", tc.Unit, f)
 	}
 }
+
+func TestParseScale(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		Expected int
+	}
+
+	suite := []testCase{
+		testCase{Unit: "m", Expected: 0},
+		testCase{Unit: "mm", Expected: -3},
+		testCase{Unit: "dam", Expected: 1},
+		testCase{Unit: "dm", Expected: -1},
+		testCase{Unit: "mmol", Expected: -3},
+		testCase{Unit: "cd", Expected: 0},
+		testCase{Unit: "kg·m/s^2", Expected: 3},
+		testCase{Unit: "N", Expected: 3},
+		testCase{Unit: "mN", Expected: 0},
+		testCase{Unit: "ml", Expected: -6},
+		testCase{Unit: "M", Expected: 3},
+		testCase{Unit: "mM", Expected: 0},
+		testCase{Unit: "Mm", Expected: 6},
+	}
+
+	for _, tc := range suite {
+		m, err := Parse(1.0, tc.Unit)
+		if err != nil {
+			t.Errorf("failed to parse %q: %s", tc.Unit, err)
+		} else if e, f := tc.Expected, m.(*measure).unit.Scale; e != f {
+			t.Errorf("failed to parse %q: expected %d found %d", tc.Unit, e, f)
+		}
+	}
+}
+
+func TestParseExponentErrors(t *testing.T) {
+	for _, u := range []string{"m^200", "m^99999", "m^-99999", "m^99999999999999999999", "m^(1/99999)", "m^99999.5"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		} else if _, ok := err.(*ExponentError); !ok {
+			t.Errorf("%q: expecting *ExponentError found %T: %s", u, err, err)
+		}
+	}
+
+	for _, u := range []string{"m^(1/0)", "m^(-3/0)"} {
+		if m, err := Parse(1.0, u); err != errDivideByZero {
+			t.Errorf("%q: expecting %v got %v, %v", u, errDivideByZero, m, err)
+		}
+	}
+}
+
+func TestSimplify(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		Expected string
+	}
+
+	suite := []testCase{
+		testCase{Unit: "m", Expected: "m"},
+		testCase{Unit: "g/g", Expected: ""},
+		testCase{Unit: "m m", Expected: "m^2"},
+		testCase{Unit: "kg·m/(s^2 s)", Expected: "kg·m/s^3"},
+		testCase{Unit: "mg/g", Expected: "mg/g"},
+	}
+
+	for _, tc := range suite {
+		m := Must(Parse(2.0, tc.Unit)).(*measure)
+		sm, err := Simplify(m)
+		if err != nil {
+			t.Errorf("%q: %s", tc.Unit, err)
+			continue
+		}
+		s := sm.(*measure).unit
+		if e, f := tc.Expected, sm.MeasurementUnit(); e != f {
+			t.Errorf("%q: expecting %q found %q", tc.Unit, e, f)
+		}
+		if e, f := 2.0, sm.Quantity(); e != f {
+			t.Errorf("%q: expecting %v found %v", tc.Unit, e, f)
+		}
+		if e, f := m.unit.product(), s.product(); e != f {
+			t.Errorf("%q: expecting %q found %q", tc.Unit, e, f)
+		}
+		if len(s.DimLess) != 0 {
+			t.Errorf("%q: expecting no dimensionless factors found %v", tc.Unit, s.DimLess)
+		}
+	}
+
+	// Simplified ratios are plain numbers
+	if m, err := New("mol/mol", Must(Simplify(Must(Parse(0.5, "g/g"))))); err != nil {
+		t.Error(err)
+	} else if e, f := 0.5, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := Simplify(Must(Parse(7.0, "pH"))); err != nil {
+		t.Error(err)
+	} else if e, f := "pH", m.MeasurementUnit(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+
+	// Dimensionless ratios are distinguishable before simplification
+	gg := Must(Parse(1.0, "g/g")).(*measure).unit
+	mm := Must(Parse(1.0, "mol/mol")).(*measure).unit
+	gr, err := gg.ratio()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mr, err := mm.ratio()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gr == mr {
+		t.Errorf("expecting different ratios found %q", gr)
+	}
+}
+
+var benchmarkUnits = []string{
+	"m", "ml", "ug/uL", "kmol / s", "kg·m/s^2", "mg/(cm)^3",
+}
+
+// Measures cache hits, not parsing; see BenchmarkParseUncached
+func BenchmarkLookupCached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, u := range benchmarkUnits {
+			if _, err := lookupUnit(u); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// Parsing is not allocation-free: each term and each intermediate product,
+// quotient or power allocates a unit. Only repeated lookups, which hit the
+// cache, are free of allocations.
+func BenchmarkParseUncached(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, u := range benchmarkUnits {
+			if _, err := defaultRegistry.parseUnitString(u); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	type testCase struct {
+		Unit   string
+		Allocs float64
+	}
+
+	suite := []testCase{
+		testCase{Unit: "kmol", Allocs: 1},
+		testCase{Unit: "kg·m", Allocs: 3},
+		testCase{Unit: "kmol/s", Allocs: 4},
+	}
+
+	for _, tc := range suite {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := defaultRegistry.parseUnitString(tc.Unit); err != nil {
+				t.Fatal(err)
+			}
+		})
+		if allocs > tc.Allocs {
+			t.Errorf("%q: expecting at most %v allocations found %v", tc.Unit, tc.Allocs, allocs)
+		}
+	}
+}
+
+func BenchmarkParseSymbol(b *testing.B) {
+	data := []byte("kmol")
+	var buf [4]trieMatch
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for _, pm := range defaultRegistry.scaleTrie.matches(data, 0, buf[:0]) {
+			defaultRegistry.parseSymbol(data, pm.End)
+		}
+	}
+}
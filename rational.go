@@ -0,0 +1,180 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"strconv"
+)
+
+var (
+	errNegativeBase = errors.New("fractional power of negative value")
+)
+
+// Range of dimension exponents. An exponent num/den in lowest terms can be
+// represented if MinExponent <= num <= MaxExponent and den <=
+// MaxExponentDenominator. The range is symmetric, so reciprocals of
+// representable units are always representable.
+const (
+	MinExponent            = -127
+	MaxExponent            = 127
+	MaxExponentDenominator = 127
+)
+
+// An ExponentError is returned when a dimension exponent is out of range,
+// e.g., when parsing m^100·m^100.
+type ExponentError struct {
+	Num int // Numerator of exponent in lowest terms
+	Den int // Denominator of exponent in lowest terms
+}
+
+func (a *ExponentError) Error() string {
+	e := strconv.Itoa(a.Num)
+	if a.Den != 1 {
+		e += "/" + strconv.Itoa(a.Den)
+	}
+	return "exponent " + e + " out of range"
+}
+
+// Component value in dimensional unit space: a rational exponent, e.g., 2
+// for m^2 or -1/2 for Hz^(-1/2). Components are always in lowest terms with a
+// positive denominator, so they can be compared with ==. The zero value is
+// zero.
+type uComponent struct {
+	num int8
+	den int8 // Denominator minus one
+}
+
+// Make a component from a fraction that is known to be in range. The
+// denominator must not be zero.
+func makeComponent(num, den int) uComponent {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := gcd(num, den); g > 1 {
+		num, den = num/g, den/g
+	}
+	return uComponent{
+		num: int8(num),
+		den: int8(den - 1),
+	}
+}
+
+// Make a component from a fraction, returning an error if it cannot be
+// represented. The denominator must not be zero.
+func checkedComponent(num, den int) (uComponent, error) {
+	if den < 0 {
+		num, den = -num, -den
+	}
+	if g := gcd(num, den); g > 1 {
+		num, den = num/g, den/g
+	}
+	if num < MinExponent || num > MaxExponent || den > MaxExponentDenominator {
+		return uComponent{}, &ExponentError{Num: num, Den: den}
+	}
+	return makeComponent(num, den), nil
+}
+
+// Make an integer component
+func intComponent(n int) uComponent {
+	return uComponent{num: int8(n)}
+}
+
+// Greatest common divisor of |a| and b > 0
+func gcd(a, b int) int {
+	if a < 0 {
+		a = -a
+	}
+	for a != 0 {
+		a, b = b%a, a
+	}
+	return b
+}
+
+func (a uComponent) Num() int {
+	return int(a.num)
+}
+
+func (a uComponent) Den() int {
+	return int(a.den) + 1
+}
+
+func (a uComponent) IsInt() bool {
+	return a.den == 0
+}
+
+// Return -1, 0 or 1 by the sign of the component
+func (a uComponent) Sign() int {
+	switch {
+	case a.num < 0:
+		return -1
+	case a.num > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (a uComponent) Add(b uComponent) (uComponent, error) {
+	return checkedComponent(a.Num()*b.Den()+b.Num()*a.Den(), a.Den()*b.Den())
+}
+
+func (a uComponent) Mul(b uComponent) (uComponent, error) {
+	return checkedComponent(a.Num()*b.Num(), a.Den()*b.Den())
+}
+
+func (a uComponent) Neg() uComponent {
+	return makeComponent(-a.Num(), a.Den())
+}
+
+func (a uComponent) Abs() uComponent {
+	if a.num < 0 {
+		return a.Neg()
+	}
+	return a
+}
+
+// Return if a < b
+func (a uComponent) Less(b uComponent) bool {
+	return a.Num()*b.Den() < b.Num()*a.Den()
+}
+
+// Return the sum of components. Intermediate sums may be out of range.
+func sumComponents(cs ...uComponent) (uComponent, error) {
+	num, den := 0, 1
+	for _, c := range cs {
+		num, den = num*c.Den()+c.Num()*den, den*c.Den()
+		if g := gcd(num, den); g > 1 {
+			num, den = num/g, den/g
+		}
+	}
+	return checkedComponent(num, den)
+}
+
+func (a uComponent) Float64() float64 {
+	return float64(a.num) / float64(a.Den())
+}
+
+// Format the component as an exponent, e.g., 2 or (1/2). The result can be
+// parsed after a ^.
+func (a uComponent) String() string {
+	s := strconv.Itoa(a.Num())
+	if !a.IsInt() {
+		s = "(" + s + "/" + strconv.Itoa(a.Den()) + ")"
+	}
+	return s
+}
+
+// Return the integer part (rounded toward negative infinity) of a scale
+// raised to an exponent and the remaining factor, e.g., 3 and 10^0.5 for
+// (10^7)^(1/2).
+func expScale(scale int, e uComponent) (int, float64) {
+	n, d := scale*e.Num(), e.Den()
+	q := n / d
+	if n%d != 0 && n < 0 {
+		q--
+	}
+	if r := n - q*d; r != 0 {
+		return q, math.Pow(10, float64(r)/float64(d))
+	}
+	return q, 1.0
+}
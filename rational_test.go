@@ -0,0 +1,214 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComponent(t *testing.T) {
+	half := makeComponent(1, 2)
+	third := makeComponent(-2, -6)
+
+	for _, tc := range []struct {
+		Op       string
+		Func     func(uComponent, uComponent) (uComponent, error)
+		A, B     uComponent
+		Expected uComponent
+	}{
+		{Op: "+", Func: uComponent.Add, A: half, B: third, Expected: makeComponent(5, 6)},
+		{Op: "+", Func: uComponent.Add, A: half, B: half, Expected: intComponent(1)},
+		{Op: "+", Func: uComponent.Add, A: half, B: half.Neg(), Expected: uComponent{}},
+		{Op: "*", Func: uComponent.Mul, A: half, B: third, Expected: makeComponent(1, 6)},
+	} {
+		if f, err := tc.Func(tc.A, tc.B); err != nil {
+			t.Errorf("%v %s %v: %s", tc.A, tc.Op, tc.B, err)
+		} else if e := tc.Expected; e != f {
+			t.Errorf("%v %s %v: expecting %v found %v", tc.A, tc.Op, tc.B, e, f)
+		}
+	}
+	if !third.Less(half) || half.Less(third) {
+		t.Errorf("expecting %v < %v", third, half)
+	}
+	if e, f := "(-1/2)", half.Neg().String(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+	if e, f := "3", intComponent(3).String(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+
+	for _, tc := range []struct {
+		Scale  int
+		E      uComponent
+		Scale2 int
+		Factor float64
+	}{
+		{Scale: 6, E: half, Scale2: 3, Factor: 1},
+		{Scale: 3, E: half, Scale2: 1, Factor: math.Sqrt(10)},
+		{Scale: -3, E: half, Scale2: -2, Factor: math.Sqrt(10)},
+		{Scale: -3, E: intComponent(-2), Scale2: 6, Factor: 1},
+	} {
+		scale, factor := expScale(tc.Scale, tc.E)
+		if scale != tc.Scale2 || math.Abs(factor-tc.Factor) > 1e-15 {
+			t.Errorf("(10^%d)^%v: expecting %d, %v found %d, %v", tc.Scale, tc.E, tc.Scale2, tc.Factor, scale, factor)
+		}
+	}
+}
+
+func TestExponentRange(t *testing.T) {
+	max := intComponent(MaxExponent)
+	min := intComponent(MinExponent)
+	if _, err := max.Add(intComponent(1)); err == nil {
+		t.Error("expecting error")
+	} else if e, ok := err.(*ExponentError); !ok {
+		t.Errorf("expecting *ExponentError found %T", err)
+	} else if e.Num != MaxExponent+1 || e.Den != 1 {
+		t.Errorf("expecting %d found %d/%d", MaxExponent+1, e.Num, e.Den)
+	}
+	if _, err := min.Mul(intComponent(2)); err == nil {
+		t.Error("expecting error")
+	}
+	if _, err := makeComponent(1, MaxExponentDenominator).Add(makeComponent(1, 2)); err == nil {
+		t.Error("expecting error")
+	}
+	if e, err := max.Add(min); err != nil || e != (uComponent{}) {
+		t.Errorf("expecting 0 found %v, %v", e, err)
+	}
+	// Partial sums may be out of range
+	if e, err := sumComponents(max, max, min); err != nil || e != max {
+		t.Errorf("expecting %v found %v, %v", max, e, err)
+	}
+
+	for _, u := range []string{
+		"m^100·m^100",
+		"m^100 m^100",
+		"m^100/m^-100",
+		"(m^100)^2",
+		"(m^(1/100))^(1/2)",
+		"m^(1/200)",
+		"m^0.0000001",
+	} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		} else if _, ok := err.(*ExponentError); !ok {
+			t.Errorf("%q: expecting *ExponentError found %T: %s", u, err, err)
+		}
+	}
+
+	for _, u := range []string{"m^127", "m^-127", "m^(1/127)", "m^100/m^100"} {
+		if _, err := Parse(1.0, u); err != nil {
+			t.Errorf("%q: %s", u, err)
+		}
+	}
+
+	// Dimensionless terms have no dimension to overflow, but their exponents
+	// are still checked when combined
+	u := Must(Parse(1.0, "%^100 %^100")).(*measure).unit
+	if s, err := u.Simplify(); err == nil {
+		t.Errorf("expecting error got %v", s)
+	} else if _, ok := err.(*ExponentError); !ok {
+		t.Errorf("expecting *ExponentError found %T: %s", err, err)
+	}
+
+	m := Must(Parse(1.0, "m^100"))
+	if v, err := New("", m, m); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+	if v, err := Pow(m, 2, 1); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+	if v, err := New("m^100", m, m, Must(Reciprocal(m))); err == nil {
+		t.Errorf("expecting error got %v", v)
+	}
+}
+
+func TestRationalExponent(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		From     Measurement
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "V/Hz^(1/2)",
+			From:     Must(Parse(1.0, "nV/Hz^0.5")),
+			Expected: 1e-9,
+		},
+		testCase{
+			Unit:     "nV/Hz^0.5",
+			From:     Must(Parse(1.0, "µV/kHz^(1/2)")),
+			Expected: 1e3 / math.Sqrt(1e3),
+		},
+		testCase{
+			Unit:     "s",
+			From:     Must(Parse(3.0, "s^(1/2) s^0.5")),
+			Expected: 3.0,
+		},
+		testCase{
+			Unit:     "m",
+			From:     Must(Parse(2.0, "cm^(2/4)·cm^(3/6)")),
+			Expected: 0.02,
+		},
+		testCase{
+			Unit:     "cm^(-3/2)",
+			From:     Must(Parse(1.0, "m^-1.5")),
+			Expected: 1e-3,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if err != nil {
+			t.Errorf("%v %s to %q: %s", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*math.Abs(e) {
+			t.Errorf("%v %s to %q: expecting %v found %v", tc.From.Quantity(), tc.From.MeasurementUnit(), tc.Unit, e, f)
+		}
+	}
+
+	for _, u := range []string{"m^(1/0)", "m^(1/2", "m^(1 2)", "m^0.", "m^(1/256)", "m^0.001"} {
+		if m, err := Parse(1.0, u); err == nil {
+			t.Errorf("%q: expecting error got %v", u, m)
+		}
+	}
+}
+
+func TestPow(t *testing.T) {
+	m, err := Sqrt(Must(Parse(4.0, "m^2/s")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := "m/s^(1/2)", m.MeasurementUnit(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+	if e, f := 2.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	m2, err := Pow(m, 2, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := New("mm^2/ms", m2); err != nil {
+		t.Error(err)
+	} else if e, f := 4e3, n.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	// Scale of prefixed terms is kept exactly
+	if n, err := Sqrt(Must(Parse(1.0, "mHz"))); err != nil {
+		t.Error(err)
+	} else if v, err := New("Hz^(1/2)", n); err != nil {
+		t.Error(err)
+	} else if e, f := math.Sqrt(1e-3), v.Quantity(); math.Abs(e-f) > 1e-15 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	if n, err := Sqrt(Must(Parse(-1.0, "m"))); err == nil {
+		t.Errorf("expecting error got %v", n)
+	}
+	if n, err := Pow(Must(Parse(1.0, "m")), 1, 0); err == nil {
+		t.Errorf("expecting error got %v", n)
+	}
+	if n, err := Sqrt(Must(Parse(7.0, "pH"))); err == nil {
+		t.Errorf("expecting error got %v", n)
+	}
+}
@@ -0,0 +1,148 @@
+package units
+
+import (
+	"html"
+	"strconv"
+	"strings"
+)
+
+// siunitx macros for prefixes
+var latexPrefixes = map[string]string{
+	"y": `\yocto`, "z": `\zepto`, "a": `\atto`, "f": `\femto`, "p": `\pico`,
+	"n": `\nano`, "μ": `\micro`, "µ": `\micro`, "u": `\micro`, "m": `\milli`,
+	"c": `\centi`, "d": `\deci`, "da": `\deca`, "h": `\hecto`, "k": `\kilo`,
+	"M": `\mega`, "G": `\giga`, "T": `\tera`, "P": `\peta`, "E": `\exa`,
+	"Z": `\zetta`, "Y": `\yotta`,
+	"Ki": `\kibi`, "Mi": `\mebi`, "Gi": `\gibi`, "Ti": `\tebi`, "Pi": `\pebi`,
+	"Ei": `\exbi`, "Zi": `\zebi`, "Yi": `\yobi`,
+}
+
+// siunitx macros for symbols. Other symbols are written as text.
+var latexSymbols = map[string]string{
+	"m": `\metre`, "g": `\gram`, "s": `\second`, "A": `\ampere`,
+	"K": `\kelvin`, "mol": `\mole`, "cd": `\candela`, "rad": `\radian`,
+	"sr": `\steradian`, "Hz": `\hertz`, "N": `\newton`, "Pa": `\pascal`,
+	"J": `\joule`, "W": `\watt`, "C": `\coulomb`, "V": `\volt`, "F": `\farad`,
+	"Ω": `\ohm`, "S": `\siemens`, "Wb": `\weber`, "T": `\tesla`, "H": `\henry`,
+	"°C": `\degreeCelsius`, "℃": `\degreeCelsius`, "lm": `\lumen`,
+	"lx": `\lux`, "Bq": `\becquerel`, "Gy": `\gray`, "Sv": `\sievert`,
+	"kat": `\katal`, "l": `\litre`, "L": `\litre`, "Da": `\dalton`,
+	"min": `\minute`, "h": `\hour`, "deg": `\degree`, "°": `\degree`,
+	"arcmin": `\arcminute`, "′": `\arcminute`, "arcsec": `\arcsecond`,
+	"″": `\arcsecond`, "B": `\byte`, "bit": `\bit`, "%": `\percent`,
+	"dB": `\decibel`,
+}
+
+// Escape text for LaTeX
+var latexReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	"{", `\{`,
+	"}", `\}`,
+	"%", `\%`,
+	"&", `\&`,
+	"#", `\#`,
+	"$", `\$`,
+	"_", `\_`,
+	"~", `\textasciitilde{}`,
+	"^", `\textasciicircum{}`,
+)
+
+// Symbols that are written without a space after the number
+var unspacedSymbols = map[string]bool{
+	"°": true, "′": true, "″": true,
+}
+
+// LaTeX formats a measurement for the siunitx package as \qty{value}{unit},
+// e.g., \qty{1.5}{\milli\litre\tothe{-1}} for 1.5 ml^-1. Dimensionless
+// measurements are formatted as \num{value}.
+func LaTeX(m Measurement) (string, error) {
+	return latex(`\qty`, m)
+}
+
+// LaTeXSI is like LaTeX but uses the \SI command of siunitx version 2.
+func LaTeXSI(m Measurement) (string, error) {
+	return latex(`\SI`, m)
+}
+
+func latex(command string, mm Measurement) (string, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return "", err
+	}
+	value := strconv.FormatFloat(m.Value, 'g', -1, 64)
+	if len(m.unit.Terms) == 0 {
+		return `\num{` + value + `}`, nil
+	}
+
+	var b strings.Builder
+	for _, t := range m.unit.Terms {
+		prefix, ok := latexPrefixes[t.Prefix]
+		if !ok && t.Prefix != "" {
+			prefix = `\text{` + latexReplacer.Replace(t.Prefix) + `}`
+		}
+		symbol, ok := latexSymbols[t.Symbol]
+		if !ok {
+			symbol = `\text{` + latexReplacer.Replace(t.Symbol) + `}`
+		}
+		b.WriteString(prefix)
+		b.WriteString(symbol)
+		switch t.Exp {
+		case intComponent(1):
+		case intComponent(2):
+			b.WriteString(`\squared`)
+		case intComponent(3):
+			b.WriteString(`\cubed`)
+		default:
+			b.WriteString(`\tothe{` + strings.Trim(t.Exp.String(), "()") + `}`)
+		}
+	}
+	return command + `{` + value + `}{` + b.String() + `}`, nil
+}
+
+// HTML formats a measurement as HTML, e.g., 1.5&#160;µl·s<sup>−1</sup> for
+// 1.5 ul/s. Exponents are written as superscripts with a minus sign, micro
+// is always µ and the number is separated from the unit by a non-breaking
+// space.
+func HTML(mm Measurement) (string, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	value := strconv.FormatFloat(m.Value, 'g', -1, 64)
+	if idx := strings.IndexByte(value, 'e'); idx >= 0 {
+		exp, _ := strconv.Atoi(value[idx+1:])
+		b.WriteString(htmlMinus(value[:idx]))
+		b.WriteString("×10<sup>" + htmlMinus(strconv.Itoa(exp)) + "</sup>")
+	} else {
+		b.WriteString(htmlMinus(value))
+	}
+
+	terms := m.unit.Terms
+	if len(terms) == 0 {
+		return b.String(), nil
+	}
+	if !(len(terms) == 1 && unspacedSymbols[terms[0].Symbol]) {
+		b.WriteString("&#160;")
+	}
+	for idx, t := range terms {
+		if idx > 0 {
+			b.WriteString("·")
+		}
+		prefix := t.Prefix
+		if prefix == "u" || prefix == "μ" {
+			prefix = "µ"
+		}
+		b.WriteString(html.EscapeString(prefix + t.Symbol))
+		if t.Exp != intComponent(1) {
+			b.WriteString("<sup>" + htmlMinus(strings.Trim(t.Exp.String(), "()")) + "</sup>")
+		}
+	}
+	return b.String(), nil
+}
+
+// Replace hyphens with minus signs
+func htmlMinus(s string) string {
+	return strings.Replace(s, "-", "−", -1)
+}
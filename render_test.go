@@ -0,0 +1,115 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestLaTeX(t *testing.T) {
+	type testCase struct {
+		Value    Measurement
+		Expected string
+	}
+
+	suite := []testCase{
+		testCase{
+			Value:    Must(Parse(1.5, "ml")),
+			Expected: `\qty{1.5}{\milli\litre}`,
+		},
+		testCase{
+			Value:    Must(Parse(101.325, "kg·m^-1·s^-2")),
+			Expected: `\qty{101.325}{\kilo\gram\metre\tothe{-1}\second\tothe{-2}}`,
+		},
+		testCase{
+			Value:    Must(Parse(9.81, "m/s^2")),
+			Expected: `\qty{9.81}{\metre\second\tothe{-2}}`,
+		},
+		testCase{
+			Value:    Must(Parse(2.0, "uM")),
+			Expected: `\qty{2}{\micro\text{M}}`,
+		},
+		testCase{
+			Value:    Must(Parse(1e-9, "m^3")),
+			Expected: `\qty{1e-09}{\metre\cubed}`,
+		},
+		testCase{
+			Value:    Must(Parse(3.0, "nV/Hz^(1/2)")),
+			Expected: `\qty{3}{\nano\volt\hertz\tothe{-1/2}}`,
+		},
+		testCase{
+			Value:    Must(Parse(5.0, "% w/v")),
+			Expected: `\qty{5}{\text{\% w/v}}`,
+		},
+		testCase{
+			Value:    Must(Parse(2.0, "MiB")),
+			Expected: `\qty{2}{\mebi\byte}`,
+		},
+		testCase{
+			Value:    Must(Parse(0.5, "")),
+			Expected: `\num{0.5}`,
+		},
+	}
+
+	for _, tc := range suite {
+		if s, err := LaTeX(tc.Value); err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, s; e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		}
+	}
+
+	if s, err := LaTeXSI(Must(Parse(37.0, "°C"))); err != nil {
+		t.Error(err)
+	} else if e, f := `\SI{37}{\degreeCelsius}`, s; e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+}
+
+func TestHTML(t *testing.T) {
+	type testCase struct {
+		Value    Measurement
+		Expected string
+	}
+
+	suite := []testCase{
+		testCase{
+			Value:    Must(Parse(1.5, "ul/s")),
+			Expected: "1.5&#160;µl·s<sup>−1</sup>",
+		},
+		testCase{
+			Value:    Must(Parse(101.325, "kg·m^-1·s^-2")),
+			Expected: "101.325&#160;kg·m<sup>−1</sup>·s<sup>−2</sup>",
+		},
+		testCase{
+			Value:    Must(Parse(10.0, "kΩ")),
+			Expected: "10&#160;kΩ",
+		},
+		testCase{
+			Value:    Must(Parse(-2.5e-7, "µm^2")),
+			Expected: "−2.5×10<sup>−7</sup>&#160;µm<sup>2</sup>",
+		},
+		testCase{
+			Value:    Must(Parse(3.0, "nV/Hz^0.5")),
+			Expected: "3&#160;nV·Hz<sup>−1/2</sup>",
+		},
+		testCase{
+			Value:    Must(Parse(90.0, "°")),
+			Expected: "90°",
+		},
+		testCase{
+			Value:    Must(Parse(37.0, "°C")),
+			Expected: "37&#160;°C",
+		},
+		testCase{
+			Value:    Must(Parse(1.0, "")),
+			Expected: "1",
+		},
+	}
+
+	for _, tc := range suite {
+		if s, err := HTML(tc.Value); err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, s; e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package units
+
+import (
+	"errors"
+	"math"
+)
+
+var (
+	errSignificantFigures = errors.New("significant figures must be positive")
+	errNonPositiveStep    = errors.New("step must be positive")
+)
+
+// A RoundingMode selects how values are rounded to a representable value
+type RoundingMode int
+
+// Rounding modes, named as in math/big. The zero value is ToNearestAway.
+const (
+	ToNearestAway RoundingMode = iota // Round half away from zero (2.5 to 3, -2.5 to -3)
+	ToNearestEven                     // Round half to even (2.5 to 2, 3.5 to 4)
+	ToPositiveInf                     // Round toward positive infinity
+	ToNegativeInf                     // Round toward negative infinity
+	ToZero                            // Round toward zero
+)
+
+// Round x to an integer
+func (a RoundingMode) round(x float64) float64 {
+	switch a {
+	case ToNearestEven:
+		return math.RoundToEven(x)
+	case ToPositiveInf:
+		return math.Ceil(x)
+	case ToNegativeInf:
+		return math.Floor(x)
+	case ToZero:
+		return math.Trunc(x)
+	default:
+		return math.Round(x)
+	}
+}
+
+// Round returns a measurement rounded to a number of significant figures,
+// keeping its unit, e.g., Round(2.9999999999999996 ml, 3, ToNearestEven) = 3 ml.
+func Round(mm Measurement, sigFigs int, mode RoundingMode) (Measurement, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return zeroValue, err
+	}
+	if sigFigs < 1 {
+		return zeroValue, errSignificantFigures
+	}
+	if !inRange(m.Value) {
+		return m, nil
+	}
+
+	// Scale so that the significant figures are the integer part. Negative
+	// powers of ten are not exact, so divide by the positive power instead.
+	p := sigFigs - 1 - int(math.Floor(math.Log10(math.Abs(m.Value))))
+	var v float64
+	if p >= 0 {
+		s := math.Pow10(p)
+		if math.IsInf(s, 0) || math.IsInf(m.Value*s, 0) {
+			return m, nil
+		}
+		v = mode.round(m.Value*s) / s
+	} else {
+		s := math.Pow10(-p)
+		v = mode.round(m.Value/s) * s
+	}
+
+	return &measure{
+		Value: v,
+		Unit:  m.Unit,
+		unit:  m.unit,
+	}, nil
+}
+
+// Quantize returns a measurement rounded to a multiple of step, keeping its
+// unit. The step may be in any unit with the same dimension, e.g., rounding to
+// the 0.1 μl resolution of a pipette, Quantize(0.0123456 ml, 0.1 μl,
+// ToNearestEven) = 0.0123 ml. An error is returned if the step has a different
+// dimension or is not positive.
+func Quantize(mm Measurement, step Measurement, mode RoundingMode) (Measurement, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return zeroValue, err
+	}
+	if m.unit.Nonlinear != nil {
+		return zeroValue, errNonlinear
+	}
+	s, err := defaultRegistry.convert(m.unit, m.Unit, step)
+	if err != nil {
+		return zeroValue, err
+	}
+	size := s.Quantity()
+	if !(size > 0.0) || math.IsInf(size, 0) {
+		return zeroValue, errNonPositiveStep
+	}
+
+	n := mode.round(m.Value / size)
+	// Steps like 0.1 are not exact, so divide by their exact reciprocal
+	// where there is one
+	var v float64
+	if inv := 1.0 / size; inv == math.Trunc(inv) {
+		v = n / inv
+	} else {
+		v = n * size
+	}
+
+	return &measure{
+		Value: v,
+		Unit:  m.Unit,
+		unit:  m.unit,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package units
+
+import (
+	"testing"
+)
+
+func TestRound(t *testing.T) {
+	type testCase struct {
+		Value    float64
+		SigFigs  int
+		Mode     RoundingMode
+		Expected float64
+	}
+
+	suite := []testCase{
+		testCase{Value: 2.9999999999999996, SigFigs: 3, Mode: ToNearestAway, Expected: 3.0},
+		testCase{Value: 1234.5, SigFigs: 2, Mode: ToNearestAway, Expected: 1200.0},
+		testCase{Value: 0.012345, SigFigs: 3, Mode: ToZero, Expected: 0.0123},
+		testCase{Value: 0.0125, SigFigs: 2, Mode: ToNearestEven, Expected: 0.012},
+		testCase{Value: 0.0135, SigFigs: 2, Mode: ToNearestEven, Expected: 0.014},
+		testCase{Value: 2.5, SigFigs: 1, Mode: ToNearestAway, Expected: 3.0},
+		testCase{Value: -2.5, SigFigs: 1, Mode: ToNearestAway, Expected: -3.0},
+		testCase{Value: -2.5, SigFigs: 1, Mode: ToNearestEven, Expected: -2.0},
+		testCase{Value: 1.01, SigFigs: 2, Mode: ToPositiveInf, Expected: 1.1},
+		testCase{Value: -1.01, SigFigs: 2, Mode: ToNegativeInf, Expected: -1.1},
+		testCase{Value: 9.96, SigFigs: 2, Mode: ToNearestAway, Expected: 10.0},
+		testCase{Value: 6.02214076e23, SigFigs: 3, Mode: ToNearestAway, Expected: 6.02e23},
+		testCase{Value: 0.0, SigFigs: 3, Mode: ToNearestAway, Expected: 0.0},
+	}
+
+	for _, tc := range suite {
+		m, err := Round(Must(Parse(tc.Value, "ml")), tc.SigFigs, tc.Mode)
+		if err != nil {
+			t.Errorf("%v: %s", tc.Value, err)
+			continue
+		}
+		if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("%v to %d: expecting %v found %v", tc.Value, tc.SigFigs, e, f)
+		}
+		if e, f := "ml", m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		}
+	}
+
+	if m, err := Round(Must(Parse(1.0, "ml")), 0, ToNearestEven); err == nil {
+		t.Errorf("expecting error got %v", m)
+	}
+}
+
+func TestQuantize(t *testing.T) {
+	type testCase struct {
+		Value      Measurement
+		Step       Measurement
+		Mode       RoundingMode
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Value:    Must(Parse(2.9999999999999996, "ml")),
+			Step:     Must(Parse(0.1, "µl")),
+			Expected: 3.0,
+		},
+		testCase{
+			Value:    Must(Parse(12.34, "µl")),
+			Step:     Must(Parse(0.1, "µl")),
+			Expected: 12.3,
+		},
+		testCase{
+			Value:    Must(Parse(12.35, "µl")),
+			Step:     Must(Parse(0.5, "µl")),
+			Mode:     ToNegativeInf,
+			Expected: 12.0,
+		},
+		testCase{
+			Value:    Must(Parse(0.25, "ml")),
+			Step:     Must(Parse(100.0, "µl")),
+			Mode:     ToNearestEven,
+			Expected: 0.2,
+		},
+		testCase{
+			Value:    Must(Parse(7.0, "min")),
+			Step:     Must(Parse(0.25, "h")),
+			Mode:     ToPositiveInf,
+			Expected: 15.0,
+		},
+		testCase{
+			Value:    Must(Parse(-1.26, "mg")),
+			Step:     Must(Parse(0.1, "mg")),
+			Mode:     ToZero,
+			Expected: -1.2,
+		},
+		testCase{
+			Value:      Must(Parse(1.0, "ml")),
+			Step:       Must(Parse(0.1, "mg")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:      Must(Parse(1.0, "ml")),
+			Step:       Must(Parse(0.0, "µl")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:      Must(Parse(1.0, "ml")),
+			Step:       Must(Parse(-1.0, "µl")),
+			ShouldFail: true,
+		},
+		testCase{
+			Value:      Must(Parse(7.03, "pH")),
+			Step:       Must(Parse(0.1, "pH")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := Quantize(tc.Value, tc.Step, tc.Mode)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("%v by %v: expecting error got %v", tc.Value, tc.Step, m)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v by %v: %s", tc.Value, tc.Step, err)
+			continue
+		}
+		if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("%v by %v: expecting %v found %v", tc.Value, tc.Step, e, f)
+		}
+		if e, f := tc.Value.MeasurementUnit(), m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		}
+	}
+}
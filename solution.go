@@ -0,0 +1,162 @@
+package units
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+)
+
+var (
+	errNegativeVolume     = errors.New("negative volume")
+	errInsufficientVolume = errors.New("insufficient volume")
+)
+
+// A Solution is a volume of liquid with the concentrations of its components.
+// Concentrations may be of any linear unit, e.g., molar (mM), mass (mg/ml),
+// percent (% w/v) or stock multiple (X).
+type Solution struct {
+	Volume     Measurement
+	Components map[string]Measurement // Concentration by component name
+}
+
+// Mix returns the solution made by combining solutions. The volume of the
+// result is in the unit of the first volume and the concentration of each
+// component is in the unit of its first occurrence. An error is returned if a
+// component has concentrations that cannot be converted into each other in
+// different solutions, e.g., mM and mg/ml or X and %.
+func Mix(solutions ...Solution) (Solution, error) {
+	if len(solutions) == 0 {
+		return Solution{}, errors.New("no solutions")
+	}
+
+	// Conversions go through parsed units rather than unit strings, so
+	// solutions may use units of any registry
+	first, err := parse(solutions[0].Volume)
+	if err != nil {
+		return Solution{}, err
+	}
+	if volume, _ := lookupUnit("l"); first.unit.Nonlinear != nil || first.unit.product() != volume.product() {
+		return Solution{}, errWrongDimension
+	}
+
+	var total float64
+	var names []string
+	units := make(map[string]*measure)
+	amounts := make(map[string]float64)
+	for _, s := range solutions {
+		v, err := convertLike(first, s.Volume)
+		if err != nil {
+			return Solution{}, err
+		}
+		if v.Quantity() < 0.0 {
+			return Solution{}, errNegativeVolume
+		}
+		total += v.Quantity()
+
+		for _, name := range sortedComponents(s.Components) {
+			c, err := parse(s.Components[name])
+			if err != nil {
+				return Solution{}, err
+			} else if c.unit.Nonlinear != nil {
+				return Solution{}, errNonlinear
+			}
+			unit, seen := units[name]
+			if !seen {
+				unit = c
+				units[name] = unit
+				names = append(names, name)
+			}
+			cc, err := convertLike(unit, c)
+			if err != nil {
+				return Solution{}, errors.New("component " + strconv.Quote(name) + ": incompatible concentrations: " + err.Error())
+			}
+			amounts[name] += cc.Quantity() * v.Quantity()
+		}
+	}
+
+	if total == 0.0 {
+		return Solution{}, errDivideByZero
+	}
+
+	r := Solution{
+		Volume:     withValue(first, total),
+		Components: make(map[string]Measurement),
+	}
+	for _, name := range names {
+		r.Components[name] = withValue(units[name], amounts[name]/total)
+	}
+	return r, nil
+}
+
+// Dilute returns the solution made by adding solvent to a up to the given
+// final volume. An error is returned if the final volume is less than the
+// volume of a.
+func (a Solution) Dilute(volume Measurement) (Solution, error) {
+	av, err := parse(a.Volume)
+	if err != nil {
+		return Solution{}, err
+	}
+	v, err := convertLike(av, volume)
+	if err != nil {
+		return Solution{}, err
+	}
+	diff := v.Quantity() - av.Value
+	if diff < 0.0 {
+		return Solution{}, errNegativeVolume
+	}
+	return Mix(a, Solution{
+		Volume: withValue(av, diff),
+	})
+}
+
+// Aliquot returns a portion of a with the given volume. An error is returned
+// if the volume is more than the volume of a.
+func (a Solution) Aliquot(volume Measurement) (Solution, error) {
+	av, err := parse(a.Volume)
+	if err != nil {
+		return Solution{}, err
+	}
+	v, err := convertLike(av, volume)
+	if err != nil {
+		return Solution{}, err
+	}
+	if v.Quantity() < 0.0 {
+		return Solution{}, errNegativeVolume
+	}
+	if v.Quantity() > av.Value {
+		return Solution{}, errInsufficientVolume
+	}
+
+	r := Solution{
+		Volume:     volume,
+		Components: make(map[string]Measurement),
+	}
+	for name, c := range a.Components {
+		r.Components[name] = c
+	}
+	return r, nil
+}
+
+// Convert a measurement to the unit of another
+func convertLike(like *measure, m Measurement) (Measurement, error) {
+	return defaultRegistry.convert(like.unit, like.Unit, m)
+}
+
+// Return a measurement with the unit of another and the given value
+func withValue(like *measure, value float64) Measurement {
+	return &measure{
+		Value: value,
+		Unit:  like.Unit,
+		unit:  like.unit,
+	}
+}
+
+// Return component names in sorted order
+func sortedComponents(components map[string]Measurement) []string {
+	var names []string
+	for name := range components {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,202 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func expectConcentrations(t *testing.T, s Solution, expected map[string]Measurement) {
+	if e, f := len(expected), len(s.Components); e != f {
+		t.Errorf("expecting %d components found %d", e, f)
+	}
+	for name, e := range expected {
+		f, ok := s.Components[name]
+		if !ok {
+			t.Errorf("expecting component %q", name)
+		} else if e.MeasurementUnit() != f.MeasurementUnit() {
+			t.Errorf("%q: expecting %q found %q", name, e.MeasurementUnit(), f.MeasurementUnit())
+		} else if math.Abs(e.Quantity()-f.Quantity()) > 1e-12*e.Quantity() {
+			t.Errorf("%q: expecting %v found %v", name, e.Quantity(), f.Quantity())
+		}
+	}
+}
+
+func TestMix(t *testing.T) {
+	buffer := Solution{
+		Volume: Must(Parse(10.0, "ml")),
+		Components: map[string]Measurement{
+			"Tris":   Must(Parse(100.0, "mM")),
+			"NaCl":   Must(Parse(5.844, "mg/ml")),
+			"buffer": Must(Parse(10.0, "X")),
+		},
+	}
+	salt := Solution{
+		Volume: Must(Parse(0.01, "L")),
+		Components: map[string]Measurement{
+			"NaCl": Must(Parse(1.0, "% w/v")),
+		},
+	}
+	water := Solution{
+		Volume: Must(Parse(80000.0, "ul")),
+	}
+
+	s, err := Mix(buffer, salt, water)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := "ml", s.Volume.MeasurementUnit(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	} else if e, f := 100.0, s.Volume.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	expectConcentrations(t, s, map[string]Measurement{
+		"Tris":   Must(Parse(10.0, "mM")),
+		"NaCl":   Must(Parse(1.5844, "mg/ml")),
+		"buffer": Must(Parse(1.0, "X")),
+	})
+
+	// Inputs are unchanged
+	if e, f := 100.0, buffer.Components["Tris"].Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
+
+func TestMixIncompatible(t *testing.T) {
+	a := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"glucose": Must(Parse(1.0, "mM")),
+		},
+	}
+	b := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"glucose": Must(Parse(1.0, "mg/ml")),
+		},
+	}
+	c := Solution{
+		Volume: Must(Parse(1.0, "g")),
+	}
+	d := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"H+": Must(Parse(7.0, "pH")),
+		},
+	}
+
+	e := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"tris": Must(Parse(10.0, "X")),
+		},
+	}
+	f := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"tris": Must(Parse(50.0, "%")),
+		},
+	}
+
+	for _, ss := range [][]Solution{{a, b}, {c}, {a, c}, {d}, {e, f}, {f, e}, {}} {
+		if s, err := Mix(ss...); err == nil {
+			t.Errorf("expecting error got %v", s)
+		}
+	}
+
+	// Stock multiples are not plain numbers
+	for _, tc := range [][2]string{{"X", "%"}, {"%", "X"}, {"X", ""}, {"X", "AU"}} {
+		if m, err := New(tc[0], Must(Parse(5.0, tc[1]))); err == nil {
+			t.Errorf("%q to %q: expecting error got %v", tc[1], tc[0], m)
+		}
+	}
+}
+
+func TestDilute(t *testing.T) {
+	stock := Solution{
+		Volume: Must(Parse(100.0, "ul")),
+		Components: map[string]Measurement{
+			"dNTP": Must(Parse(10.0, "mM")),
+		},
+	}
+
+	s, err := stock.Dilute(Must(Parse(1.0, "ml")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 1000.0, s.Volume.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	expectConcentrations(t, s, map[string]Measurement{
+		"dNTP": Must(Parse(1.0, "mM")),
+	})
+
+	if s, err := stock.Dilute(Must(Parse(10.0, "ul"))); err == nil {
+		t.Errorf("expecting error got %v", s)
+	}
+}
+
+func TestAliquot(t *testing.T) {
+	stock := Solution{
+		Volume: Must(Parse(1.0, "ml")),
+		Components: map[string]Measurement{
+			"dNTP": Must(Parse(10.0, "mM")),
+		},
+	}
+
+	s, err := stock.Aliquot(Must(Parse(50.0, "ul")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e, f := 50.0, s.Volume.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	expectConcentrations(t, s, stock.Components)
+
+	for _, v := range []Measurement{Must(Parse(2.0, "ml")), Must(Parse(-1.0, "ul")), Must(Parse(1.0, "g"))} {
+		if s, err := stock.Aliquot(v); err == nil {
+			t.Errorf("expecting error got %v", s)
+		}
+	}
+}
+
+func TestMixRegistry(t *testing.T) {
+	r, err := NewRegistry(USCustomary)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := Solution{
+		Volume: Must(r.Parse(1.0, "fl oz")),
+		Components: map[string]Measurement{
+			"NaCl": Must(r.Parse(1.0, "oz/gal")),
+		},
+	}
+	b := Solution{
+		Volume: Must(Parse(29.5735295625, "ml")),
+	}
+
+	for _, ss := range [][]Solution{{a, b}, {b, a}} {
+		s, err := Mix(ss...)
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		v := Must(r.New("fl oz", s.Volume))
+		if e, f := 2.0, v.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+		expectConcentrations(t, s, map[string]Measurement{
+			"NaCl": Must(r.Parse(0.5, "oz/gal")),
+		})
+	}
+
+	if s, err := a.Dilute(Must(Parse(59.147059125, "ml"))); err != nil {
+		t.Error(err)
+	} else if e, f := 2.0, s.Volume.Quantity(); math.Abs(e-f) > 1e-12*e {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if s, err := a.Aliquot(Must(Parse(10.0, "ml"))); err != nil {
+		t.Error(err)
+	} else if e, f := "ml", s.Volume.MeasurementUnit(); e != f {
+		t.Errorf("expecting %q found %q", e, f)
+	}
+}
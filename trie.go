@@ -0,0 +1,75 @@
+package units
+
+import (
+	"unicode/utf8"
+)
+
+// A trie over runes mapping keys to indices. Lookups walk the input directly
+// and do not allocate.
+type trie struct {
+	next  map[rune]*trie
+	value int // Index of key ending at this node or -1 if none
+}
+
+// A key found in the input
+type trieMatch struct {
+	Value int // Index of key
+	End   int // Position in input after key
+}
+
+func newTrie() *trie {
+	return &trie{
+		value: -1,
+	}
+}
+
+func (a *trie) insert(key string, value int) {
+	node := a
+	for _, r := range key {
+		child := node.next[r]
+		if child == nil {
+			if node.next == nil {
+				node.next = make(map[rune]*trie)
+			}
+			child = newTrie()
+			node.next[r] = child
+		}
+		node = child
+	}
+	node.value = value
+}
+
+// Append all keys that are prefixes of data[pos:] to buf, shortest first
+func (a *trie) matches(data []byte, pos int, buf []trieMatch) []trieMatch {
+	node := a
+	for pos < len(data) {
+		r, width := utf8.DecodeRune(data[pos:])
+		if node = node.next[r]; node == nil {
+			break
+		}
+		pos += width
+		if node.value >= 0 {
+			buf = append(buf, trieMatch{Value: node.value, End: pos})
+		}
+	}
+	return buf
+}
+
+// Return the longest key that is a prefix of data[pos:]
+func (a *trie) longest(data []byte, pos int) (trieMatch, bool) {
+	var m trieMatch
+	found := false
+	node := a
+	for pos < len(data) {
+		r, width := utf8.DecodeRune(data[pos:])
+		if node = node.next[r]; node == nil {
+			break
+		}
+		pos += width
+		if node.value >= 0 {
+			m = trieMatch{Value: node.value, End: pos}
+			found = true
+		}
+	}
+	return m, found
+}
@@ -0,0 +1,82 @@
+package units
+
+import "testing"
+
+func TestTrie(t *testing.T) {
+	tr := newTrie()
+	for idx, key := range []string{"m", "mol", "μ", "°C"} {
+		tr.insert(key, idx)
+	}
+
+	type testCase struct {
+		Input    string
+		Pos      int
+		Expected []trieMatch
+	}
+
+	suite := []testCase{
+		testCase{
+			Input:    "mol",
+			Expected: []trieMatch{{Value: 0, End: 1}, {Value: 1, End: 3}},
+		},
+		testCase{
+			Input:    "mo",
+			Expected: []trieMatch{{Value: 0, End: 1}},
+		},
+		testCase{
+			Input:    "k°C",
+			Pos:      1,
+			Expected: []trieMatch{{Value: 3, End: 4}},
+		},
+		testCase{
+			Input:    "μm",
+			Expected: []trieMatch{{Value: 2, End: 2}},
+		},
+		testCase{
+			Input: "g",
+		},
+		testCase{
+			Input: "",
+		},
+	}
+
+	for _, tc := range suite {
+		data := []byte(tc.Input)
+		found := tr.matches(data, tc.Pos, nil)
+		if e, f := len(tc.Expected), len(found); e != f {
+			t.Errorf("%q: expecting %d matches found %v", tc.Input, e, found)
+			continue
+		}
+		for idx, e := range tc.Expected {
+			if f := found[idx]; e != f {
+				t.Errorf("%q: expecting %v found %v", tc.Input, e, f)
+			}
+		}
+
+		m, ok := tr.longest(data, tc.Pos)
+		if len(tc.Expected) == 0 {
+			if ok {
+				t.Errorf("%q: expecting no match found %v", tc.Input, m)
+			}
+		} else if e := tc.Expected[len(tc.Expected)-1]; !ok || e != m {
+			t.Errorf("%q: expecting %v found %v", tc.Input, e, m)
+		}
+	}
+}
+
+func TestCachedLookupAllocs(t *testing.T) {
+	data := []byte("kmol/s")
+	var buf [4]trieMatch
+	allocs := testing.AllocsPerRun(100, func() {
+		defaultRegistry.scaleTrie.matches(data, 0, buf[:0])
+		if _, _, err := defaultRegistry.parseSymbol(data, 1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := lookupUnit("kmol/s"); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if allocs != 0 {
+		t.Errorf("expecting no allocations found %v", allocs)
+	}
+}
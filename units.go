@@ -4,7 +4,6 @@ package units
 import (
 	"errors"
 	"math"
-	"strconv"
 	"strings"
 )
 
@@ -16,6 +15,7 @@ const (
 var (
 	errDivideByZero   = errors.New("divide by zero")
 	errWrongDimension = errors.New("wrong dimension")
+	errWrongRatio     = errors.New("wrong dimensionless ratio")
 	errUnderflow      = errors.New("underflow")
 	errOverflow       = errors.New("overflow")
 )
@@ -37,6 +37,11 @@ const (
 	timeDim                // T
 	temperatureDim         // Θ: Absolute temperature
 	temperatureCDim        // ΘC: Celsius temperature
+	nucleotideDim          // nt: Sequence length in nucleotides or base pairs
+	cellDim                // cell: Number of cells
+	colonyDim              // CFU: Colony forming units
+	copyDim                // copy: Copies of a molecule (e.g., a genome)
+	informationDim         // bit: Information
 	numDim
 )
 
@@ -44,37 +49,192 @@ const (
 // dimensions grows. Currently sizeof(uPoint) ~ sizeof(float64) so it's not a
 // big deal now.
 
-// Component value in dimensional unit space
-type uComponent int8
-
 // Point in dimensional unit space
-type uPoint [numDim]uComponent
+type uPoint struct {
+	// Exponents of the base dimensions
+	base [numDim]uComponent
+	// Exponents of dimensions defined with Registry.DefineDimension, encoded
+	// with encodeUserDims; empty for most units
+	user string
+}
+
+// Labels of the base dimensions
+var dimLabels = [numDim]string{
+	"I", "J", "L", "M", "N", "T", "Θ", "ΘC", "nt", "cell", "CFU", "copy", "bit",
+}
 
 func (a uPoint) String() string {
-	labels := []string{
-		"I", "J", "L", "M", "N", "T", "Θ", "ΘC",
-	}
 	var terms []string
-	for idx, v := range a {
-		if v == 0 {
+	for idx, v := range a.base {
+		if v == (uComponent{}) {
 			continue
 		}
-		label := "X"
-		if idx < len(labels) {
-			label = labels[idx]
-		}
-		terms = append(terms, label+"^"+strconv.FormatInt(int64(v), 10))
+		terms = append(terms, dimLabels[idx]+"^"+v.String())
+	}
+	for _, d := range decodeUserDims(a.user) {
+		terms = append(terms, d.Label+"^"+d.Exp.String())
 	}
 	return strings.Join(terms, " ")
 }
 
-// A Measurement is a value with dimensional unit
+// A Measurement is a value with dimensional unit.
+//
+// Measurements returned by this package are immutable: operations never
+// modify their arguments and always return fresh results, so measurements can
+// be shared freely between goroutines.
 type Measurement interface {
 	Quantity() float64
 	MeasurementUnit() string
 }
 
-// Parsed unit
+// Sum of two points
+func (a uPoint) add(b uPoint) (uPoint, error) {
+	var err error
+	for idx, v := range b.base {
+		if a.base[idx], err = a.base[idx].Add(v); err != nil {
+			return uPoint{}, err
+		}
+	}
+	if b.user != "" {
+		if a.user, err = sumUserDims(a.user, b.user); err != nil {
+			return uPoint{}, err
+		}
+	}
+	return a, nil
+}
+
+// Point scaled by an exponent
+func (a uPoint) exp(e uComponent) (uPoint, error) {
+	var err error
+	for idx, v := range a.base {
+		if a.base[idx], err = e.Mul(v); err != nil {
+			return uPoint{}, err
+		}
+	}
+	if a.user != "" {
+		ds := decodeUserDims(a.user)
+		for idx := range ds {
+			if ds[idx].Exp, err = e.Mul(ds[idx].Exp); err != nil {
+				return uPoint{}, err
+			}
+		}
+		a.user = encodeUserDims(ds)
+	}
+	return a, nil
+}
+
+// Point with only the positive exponents of a point
+func (a uPoint) positive() uPoint {
+	var r uPoint
+	for idx, v := range a.base {
+		if v.Sign() > 0 {
+			r.base[idx] = v
+		}
+	}
+	if a.user != "" {
+		var ds []userDim
+		for _, d := range decodeUserDims(a.user) {
+			if d.Exp.Sign() > 0 {
+				ds = append(ds, d)
+			}
+		}
+		r.user = encodeUserDims(ds)
+	}
+	return r
+}
+
+// Dimensions that cancel in the product of two points, e.g., L^2 for L^2·T
+// and L^-3
+func cancelled(a, b uPoint) uPoint {
+	var r uPoint
+	for idx, v := range a.base {
+		r.base[idx] = cancelledComponent(v, b.base[idx])
+	}
+	if a.user != "" && b.user != "" {
+		bs := decodeUserDims(b.user)
+		var ds []userDim
+		for _, d := range decodeUserDims(a.user) {
+			for _, e := range bs {
+				if d.Label == e.Label {
+					ds = append(ds, userDim{Label: d.Label, Exp: cancelledComponent(d.Exp, e.Exp)})
+				}
+			}
+		}
+		r.user = encodeUserDims(ds)
+	}
+	return r
+}
+
+// Part of two exponents that cancels in their sum
+func cancelledComponent(v, w uComponent) uComponent {
+	if v.Sign()*w.Sign() >= 0 {
+		return uComponent{}
+	}
+	c := v.Abs()
+	if w.Abs().Less(c) {
+		c = w.Abs()
+	}
+	return c
+}
+
+// Sum of points. Intermediate sums may be out of range.
+func sumPoints(ps ...uPoint) (uPoint, error) {
+	var r uPoint
+	var cs []uComponent
+	for idx := range r.base {
+		cs = cs[:0]
+		for _, p := range ps {
+			if p.base[idx] != (uComponent{}) {
+				cs = append(cs, p.base[idx])
+			}
+		}
+		if len(cs) == 0 {
+			continue
+		}
+		c, err := sumComponents(cs...)
+		if err != nil {
+			return uPoint{}, err
+		}
+		r.base[idx] = c
+	}
+
+	var users []string
+	for _, p := range ps {
+		if p.user != "" {
+			users = append(users, p.user)
+		}
+	}
+	if len(users) != 0 {
+		user, err := sumUserDims(users...)
+		if err != nil {
+			return uPoint{}, err
+		}
+		r.user = user
+	}
+	return r, nil
+}
+
+// A factor of a unit as written, e.g., the ms^-1 of m/ms
+type uTerm struct {
+	Prefix string
+	Symbol string
+	Exp    uComponent
+}
+
+func (a uTerm) String() string {
+	s := a.Prefix + a.Symbol
+	if a.Exp != intComponent(1) {
+		s += "^" + a.Exp.String()
+	}
+	return s
+}
+
+// Parsed unit.
+//
+// Operations on parsed units are not normalizing: the terms a unit was written
+// with and the factors of dimensionless ratios are preserved, so g/g and
+// mol/mol remain distinguishable even though both are dimensionless. Use
+// Simplify to get the normalized form.
 type pUnit struct {
 	// For normal units, the unit dimensions
 	Dim uPoint
@@ -82,59 +242,234 @@ type pUnit struct {
 	// be one).
 	DimLess []uPoint
 	Scale   int
+	// Conversion factor for units that are not a decimal multiple of the
+	// base units, e.g., 60 for minutes. Zero means one.
+	Factor float64
+	// Terms as written; empty for dimensionless numbers
+	Terms []uTerm
+	// For nonlinear units, the mapping to the linear unit. Nonlinear units
+	// are never combined with other units.
+	Nonlinear *nonlinearUnit
+	// Kinds of quantity, for units whose dimension is shared by different
+	// kinds of quantities; empty for kindless units
+	Kinds []uKind
 }
 
-// TODO: Currently pUnit operations are strongly normalizing. Need to revisit
-// for better support for dimensionless values
+// Number of terms allocated together with a unit by newUnit
+const inlineTerms = 4
+
+// Return an empty unit with room for n terms. Most units have only a few
+// terms, so those are allocated together with the unit.
+func newUnit(n int) *pUnit {
+	if n == 0 {
+		return &pUnit{}
+	} else if n > inlineTerms {
+		return &pUnit{
+			Terms: make([]uTerm, 0, n),
+		}
+	}
+	t := &struct {
+		unit  pUnit
+		terms [inlineTerms]uTerm
+	}{}
+	t.unit.Terms = t.terms[:0:n]
+	return &t.unit
+}
 
 // Return product of all dimension factors
 func (a *pUnit) product() uPoint {
-	var newDim uPoint
-	for _, dim := range append(a.DimLess, a.Dim) {
-		for idx, v := range dim {
-			newDim[idx] += v
-		}
+	if len(a.DimLess) == 0 {
+		return a.Dim
 	}
-	return newDim
+	// Dimensionless factors multiply to one, so the product is always in
+	// range even if partial products are not
+	p, _ := sumPoints(append([]uPoint{a.Dim}, a.DimLess...)...)
+	return p
 }
 
-func (a *pUnit) Multiply(b *pUnit) *pUnit {
-	r := a.product()
-	for idx, v := range b.product() {
-		r[idx] += v
+// Return the dimension that a dimensionless ratio is a ratio of, e.g., M for
+// g/g and L^2 for sr, or the zero point if the unit has no dimensionless
+// factors.
+func (a *pUnit) ratio() (uPoint, error) {
+	var ps []uPoint
+	for _, dim := range a.DimLess {
+		ps = append(ps, dim.positive())
 	}
+	return sumPoints(ps...)
+}
 
-	return &pUnit{
-		Dim:   r,
-		Scale: a.Scale + b.Scale,
+// Return the non-decimal conversion factor of a unit
+func (a *pUnit) factor() float64 {
+	if a.Factor == 0.0 {
+		return 1.0
+	}
+	return a.Factor
+}
+
+func (a *pUnit) Multiply(b *pUnit) (*pUnit, error) {
+	dim, err := a.Dim.add(b.Dim)
+	if err != nil {
+		return nil, err
+	}
+
+	// Dimensions that cancel become a dimensionless ratio
+	num := cancelled(a.Dim, b.Dim)
+	// Negation cannot fail as the exponent range is symmetric
+	den, _ := num.exp(intComponent(-1))
+
+	var dimLess []uPoint
+	if n := len(a.DimLess) + len(b.DimLess); n != 0 || num != (uPoint{}) {
+		dimLess = make([]uPoint, 0, n+2)
+		dimLess = append(dimLess, a.DimLess...)
+		dimLess = append(dimLess, b.DimLess...)
+		if num != (uPoint{}) {
+			dimLess = append(dimLess, num, den)
+		}
+	}
+
+	kinds, err := multiplyKinds(a.Kinds, b.Kinds)
+	if err != nil {
+		return nil, err
 	}
+
+	r := newUnit(len(a.Terms) + len(b.Terms))
+	r.Dim = dim
+	r.DimLess = dimLess
+	r.Scale = a.Scale + b.Scale
+	r.Factor = a.factor() * b.factor()
+	r.Terms = append(r.Terms, a.Terms...)
+	r.Terms = append(r.Terms, b.Terms...)
+	r.Kinds = kinds
+	return r, nil
 }
 
 func (a *pUnit) Reciprocal() *pUnit {
-	r := a.product()
-	for idx, v := range r {
-		r[idx] = -v
+	// The exponent range is symmetric, so negation cannot fail
+	r, _ := a.Exp(intComponent(-1))
+	return r
+}
+
+func (a *pUnit) Exp(e uComponent) (*pUnit, error) {
+	dim, err := a.Dim.exp(e)
+	if err != nil {
+		return nil, err
 	}
 
-	return &pUnit{
-		Dim:   r,
-		Scale: -a.Scale,
+	var dimLess []uPoint
+	if len(a.DimLess) != 0 {
+		dimLess = make([]uPoint, len(a.DimLess))
+		for idx, d := range a.DimLess {
+			if dimLess[idx], err = d.exp(e); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	r := newUnit(len(a.Terms))
+	for _, t := range a.Terms {
+		if t.Exp, err = t.Exp.Mul(e); err != nil {
+			return nil, err
+		}
+		r.Terms = append(r.Terms, t)
 	}
+
+	kinds, err := expKinds(a.Kinds, e)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fractional powers of ten are folded into the factor
+	scale, scaleFactor := expScale(a.Scale, e)
+	factor := math.Pow(a.factor(), e.Float64()) * scaleFactor
+	if factor == 1.0 {
+		factor = 0.0
+	}
+
+	r.Dim = dim
+	r.DimLess = dimLess
+	r.Scale = scale
+	r.Factor = factor
+	r.Kinds = kinds
+	return r, nil
 }
 
-func (a *pUnit) Exp(e uComponent) *pUnit {
-	r := a.product()
-	for idx, v := range r {
-		r[idx] = e * v
+// Simplify returns the normalized form of a unit: dimensionless factors are
+// folded into Dim and repeated terms are combined. E.g., g/g simplifies to a
+// dimensionless number and m·m to m^2.
+func (a *pUnit) Simplify() (*pUnit, error) {
+	// Exponents of each distinct term, summed at the end so that partial sums
+	// may be out of range
+	var terms []uTerm
+	var exps [][]uComponent
+	for _, t := range a.Terms {
+		found := false
+		for idx := range terms {
+			if terms[idx].Prefix == t.Prefix && terms[idx].Symbol == t.Symbol {
+				exps[idx] = append(exps[idx], t.Exp)
+				found = true
+				break
+			}
+		}
+		if !found {
+			terms = append(terms, t)
+			exps = append(exps, []uComponent{t.Exp})
+		}
+	}
+
+	var nonZero []uTerm
+	for idx, t := range terms {
+		e, err := sumComponents(exps[idx]...)
+		if err != nil {
+			return nil, err
+		}
+		if e.Sign() != 0 {
+			t.Exp = e
+			nonZero = append(nonZero, t)
+		}
 	}
 
 	return &pUnit{
-		Dim:   r,
-		Scale: int(e) * a.Scale,
+		Dim:    a.product(),
+		Scale:  a.Scale,
+		Factor: a.Factor,
+		Terms:  nonZero,
+		Kinds:  a.Kinds,
+	}, nil
+}
+
+// String formats the terms of a unit, e.g., kg·m/s^2. The result can be
+// parsed back into an equivalent unit.
+func (a *pUnit) String() string {
+	hasNum := false
+	for _, t := range a.Terms {
+		if t.Exp.Sign() > 0 {
+			hasNum = true
+		}
+	}
+
+	var num, den []string
+	for _, t := range a.Terms {
+		if t.Exp.Sign() < 0 && hasNum {
+			t.Exp = t.Exp.Neg()
+			den = append(den, t.String())
+		} else {
+			num = append(num, t.String())
+		}
+	}
+
+	s := strings.Join(num, "·")
+	switch len(den) {
+	case 0:
+	case 1:
+		s += "/" + den[0]
+	default:
+		s += "/(" + strings.Join(den, "·") + ")"
 	}
+	return s
 }
 
-// Parsed measurement
+// Parsed measurement. A measure and its parsed unit must not be modified after
+// construction; parsed units in particular are shared through the unit cache.
 type measure struct {
 	Value float64 // Quantity value
 	Unit  string  // Given unit
@@ -149,6 +484,19 @@ func (a *measure) MeasurementUnit() string {
 	return a.Unit
 }
 
+// Return if two dimensionless ratios may be converted between each other. Plain
+// numbers are compatible with any ratio.
+func compatibleRatios(a, b uPoint) bool {
+	var zero uPoint
+	return a == zero || b == zero || a == b
+}
+
+// Return if a measurement has the same dimensions as a unit
+func hasDimension(m *measure, unitString string) bool {
+	u, err := lookupUnit(unitString)
+	return err == nil && m.unit.Nonlinear == nil && m.unit.product() == u.product()
+}
+
 // Reciprocal returns the reciprocal of a measurement. E.g., Reciprocal(2 m/s)
 // = 1/2 s/m.  The unit of the reciprocal is implementation dependent; use New
 // to convert it to a specific unit of measure.
@@ -157,74 +505,224 @@ func Reciprocal(mm Measurement) (Measurement, error) {
 	if err != nil {
 		return zeroValue, err
 	}
+	if m.unit.Nonlinear != nil {
+		return zeroValue, errNonlinear
+	}
 	if m.Value == 0.0 {
 		return zeroValue, errDivideByZero
 	}
-	m.Value = 1.0 / m.Value
-	m.unit = m.unit.Reciprocal()
-	if len(m.Unit) != 0 {
-		// TODO: canonicalize?
-		m.Unit = "(" + m.Unit + ")^-1"
+	unit := m.unit.Reciprocal()
+	return &measure{
+		Value: 1.0 / m.Value,
+		Unit:  unit.String(),
+		unit:  unit,
+	}, nil
+}
+
+// Pow returns a measurement raised to the rational power num/den, e.g.,
+// Pow(4 m^2, 1, 2) = 2 m. The exponents of the unit are exact, so Pow(Pow(m,
+// 1, 2), 2, 1) has the dimension of m. The unit of the result is
+// implementation dependent; use New to convert it to a specific unit of
+// measure.
+func Pow(mm Measurement, num, den int) (Measurement, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return zeroValue, err
+	}
+	if m.unit.Nonlinear != nil {
+		return zeroValue, errNonlinear
+	}
+	if den == 0 {
+		return zeroValue, errDivideByZero
+	}
+	e, err := checkedComponent(num, den)
+	if err != nil {
+		return zeroValue, err
+	}
+	v := math.Pow(m.Value, e.Float64())
+	if math.IsNaN(v) {
+		return zeroValue, errNegativeBase
+	}
+	unit, err := m.unit.Exp(e)
+	if err != nil {
+		return zeroValue, err
 	}
-	return m, nil
+	return &measure{
+		Value: v,
+		Unit:  unit.String(),
+		unit:  unit,
+	}, nil
+}
+
+// Sqrt returns the square root of a measurement, e.g., Sqrt(4 Hz) = 2
+// Hz^(1/2).
+func Sqrt(m Measurement) (Measurement, error) {
+	return Pow(m, 1, 2)
+}
+
+// Simplify returns a measurement with the normalized form of its unit:
+// dimensionless ratios are dropped and repeated terms are combined, e.g.,
+// Simplify(2 g/g) = 2 and Simplify(2 m·m) = 2 m^2. The value is unchanged.
+// Nonlinear measurements are returned as is.
+func Simplify(mm Measurement) (Measurement, error) {
+	m, err := parse(mm)
+	if err != nil {
+		return zeroValue, err
+	}
+	if m.unit.Nonlinear != nil {
+		return m, nil
+	}
+	unit, err := m.unit.Simplify()
+	if err != nil {
+		return zeroValue, err
+	}
+	return &measure{
+		Value: m.Value,
+		Unit:  unit.String(),
+		unit:  unit,
+	}, nil
 }
 
 // New converts one measurement to another dimension or scale by applying
 // conversion factors.
 //
-// The units for intermediate terms is unspecified and may change. If either an
-// overflow or underflow occurs, an error will be returned.
+// Dimensionless ratios of different quantities are not interchangeable: 1 g/g
+// can be converted to mg/kg or to a plain number, but not to mol/mol.
+//
+// Quantities of different kinds with the same dimension, like Hz or rpm and
+// Bq, or Gy and Sv, cannot be converted between each other except through
+// WithoutKind.
+// Kindless units, like s^-1, can be converted to and from any kind, except
+// that absorbance (AU, OD) and stock multiples (X) cannot be converted to or
+// from plain numbers or other dimensionless units. Kinds are only attached to
+// named units, so products of units are kindless: energy (J) and torque (N·m)
+// are deliberately not told apart, since a kind on J would also stop Gy from
+// converting to J/kg.
+//
+// Angles (rad, deg, rev, ...) are a kind of their own, so frequencies, like Hz
+// or rpm, and angular velocities, like rad/s or rev/min, cannot be converted
+// between each other, although both convert to and from s^-1.
+//
+// Nonlinear measurements, like pH, may be converted to and from their linear
+// form (e.g., mol/L) but cannot be combined with other measurements.
+//
+// The units for intermediate terms is unspecified and may change.
+// Intermediate products are computed with an extended exponent range, so an
+// overflow or underflow error is only returned if the final value cannot be
+// represented.
 func New(unitString string, m0 Measurement, ms ...Measurement) (Measurement, error) {
-	m, err := parse(m0)
+	return defaultRegistry.New(unitString, m0, ms...)
+}
+
+// New is like the package-level New but also accepts the optional units of
+// the registry.
+func (r *Registry) New(unitString string, m0 Measurement, ms ...Measurement) (Measurement, error) {
+	target, err := r.lookup(unitString)
 	if err != nil {
 		return zeroValue, err
 	}
+	return r.convert(target, unitString, m0, ms...)
+}
 
+// Convert the product of measurements to a parsed unit
+func (r *Registry) convert(target *pUnit, unitString string, m0 Measurement, ms ...Measurement) (Measurement, error) {
+	m, err := r.parse(m0)
+	if err != nil {
+		return zeroValue, err
+	}
+
+	// Nonlinear quantities are converted to their linear form. They cannot
+	// be multiplied with other quantities.
+	if m.unit.Nonlinear != nil {
+		if len(ms) != 0 {
+			return zeroValue, errNonlinear
+		}
+		if m, err = m.unit.Nonlinear.Linear(m.Value); err != nil {
+			return zeroValue, err
+		}
+	}
+
+	// Carry the value with an extended exponent range so that intermediate
+	// products only fail if the final value is out of range
 	unit := m.unit
-	value := m.Value
+	value := makeExtFloat(m.Value)
+	// A product with an exact zero is zero, not an underflow
+	zero := m.Value == 0.0
+	// Dimensionless ratios of the inputs; dimensions that cancel between
+	// inputs do not count
+	ratio, err := m.unit.ratio()
+	if err != nil {
+		return zeroValue, err
+	}
+	ratios := []uPoint{ratio}
 	for _, mm := range ms {
-		m, err := parse(mm)
+		m, err := r.parse(mm)
 		if err != nil {
 			return zeroValue, err
 		}
+		if m.unit.Nonlinear != nil {
+			return zeroValue, errNonlinear
+		}
 
-		value *= m.Value
-		unit = unit.Multiply(m.unit)
+		value = value.Multiply(makeExtFloat(m.Value))
+		zero = zero || m.Value == 0.0
+		if unit, err = unit.Multiply(m.unit); err != nil {
+			return zeroValue, err
+		}
+		r, err := m.unit.ratio()
+		if err != nil {
+			return zeroValue, err
+		}
+		ratios = append(ratios, r)
 	}
-
-	targetM, err := Parse(0.0, unitString)
-	if err != nil {
+	if ratio, err = sumPoints(ratios...); err != nil {
 		return zeroValue, err
 	}
-	target := targetM.(*measure)
 
-	if target.unit.product() != unit.product() {
-		return zeroValue, errWrongDimension
+	linearTarget := target
+	if target.Nonlinear != nil {
+		linearTarget = target.Nonlinear.ref
 	}
 
-	// Special case: allow us to create zero values if there were no
-	// conversions
-	if value == 0.0 && len(ms) == 0 {
-		return &measure{
-			Value: value,
-			Unit:  unitString,
-			unit:  unit,
-		}, nil
+	if linearTarget.product() != unit.product() {
+		return zeroValue, errWrongDimension
+	}
+	targetRatio, err := linearTarget.ratio()
+	if err != nil {
+		return zeroValue, err
+	}
+	if !compatibleRatios(targetRatio, ratio) {
+		return zeroValue, errWrongRatio
+	}
+	if !compatibleKinds(linearTarget.Kinds, unit.Kinds) {
+		return zeroValue, errWrongKind
 	}
 
-	scaleDiff := unit.Scale - target.unit.Scale
-	value *= math.Pow10(scaleDiff)
-	if value == 0.0 {
-		return zeroValue, errUnderflow
+	var v float64
+	if zero {
+		v = 0.0
+	} else {
+		scaleDiff := unit.Scale - linearTarget.Scale
+		factor := unit.factor() / linearTarget.factor()
+		v = value.Multiply(makeExtFloat(factor)).MultiplyPow10(scaleDiff).Float64()
+		if v == 0.0 {
+			return zeroValue, errUnderflow
+		}
+		if math.IsInf(v, 0) {
+			return zeroValue, errOverflow
+		}
 	}
-	if math.IsInf(value, 0) {
-		return zeroValue, errOverflow
+
+	if target.Nonlinear != nil {
+		if v, err = target.Nonlinear.Nonlinear(v); err != nil {
+			return zeroValue, err
+		}
 	}
 
 	return &measure{
-		Value: value,
+		Value: v,
 		Unit:  unitString,
-		unit:  unit,
+		unit:  target,
 	}, nil
 }
 
@@ -2,6 +2,7 @@ package units
 
 import (
 	"math"
+	"sync"
 	"testing"
 )
 
@@ -29,7 +30,29 @@ func TestNew(t *testing.T) {
 		t.Error(err)
 	} else if e, f := "mg/(cm)^3", m.MeasurementUnit(); e != f {
 		t.Errorf("expecting %q found %q", e, f)
-	} else if e, f := 0.5, m.Quantity(); e != f {
+	} else if e, f := 500.0, m.Quantity(); e != f {
+		// 1 g / 2 ml = 0.5 g/cm^3 = 500 mg/cm^3
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	m, err = New("N", Must(Parse(2.0, "kg·m/s^2")))
+	if err != nil {
+		t.Error(err)
+	} else if e, f := 2.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	m, err = New("m", Must(New("mm", Must(Parse(3.0, "m")))))
+	if err != nil {
+		t.Error(err)
+	} else if e, f := 3.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	m, err = New("l", Must(Parse(1.0, "m^3")))
+	if err != nil {
+		t.Error(err)
+	} else if e, f := 1000.0, m.Quantity(); e != f {
 		t.Errorf("expecting %v found %v", e, f)
 	}
 
@@ -59,6 +82,252 @@ func TestDimensionLess(t *testing.T) {
 	}
 }
 
+func TestDimensionLessRatio(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		From       []Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "mg/kg",
+			From:     []Measurement{Must(Parse(1.0, "g/g"))},
+			Expected: 1e6,
+		},
+		testCase{
+			Unit:     "",
+			From:     []Measurement{Must(Parse(0.5, "mol/mol"))},
+			Expected: 0.5,
+		},
+		testCase{
+			Unit:     "mol/mol",
+			From:     []Measurement{Must(Parse(0.5, ""))},
+			Expected: 0.5,
+		},
+		testCase{
+			Unit:     "sr",
+			From:     []Measurement{Must(Parse(2.0, "rad")), Must(Parse(3.0, "rad"))},
+			Expected: 6.0,
+		},
+		testCase{
+			Unit:     "rad",
+			From:     []Measurement{Must(Parse(2.0, "rad/s")), Must(Parse(3.0, "s"))},
+			Expected: 6.0,
+		},
+		testCase{
+			Unit:       "g/g",
+			From:       []Measurement{Must(Parse(1.0, "mol/mol"))},
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "rad",
+			From:       []Measurement{Must(Parse(1.0, "sr"))},
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From[0], tc.From[1:]...)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+		} else if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.Quantity(); e != f {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestPercent(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		From       Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "g/L",
+			From:     Must(Parse(2.0, "% w/v")),
+			Expected: 20.0,
+		},
+		testCase{
+			Unit:     "% (w/v)",
+			From:     Must(Parse(5.0, "mg/ml")),
+			Expected: 0.5,
+		},
+		testCase{
+			Unit:     "ppm",
+			From:     Must(Parse(1.0, "%")),
+			Expected: 1e4,
+		},
+		testCase{
+			Unit:     "mg/kg",
+			From:     Must(Parse(1.0, "%w/w")),
+			Expected: 1e4,
+		},
+		testCase{
+			Unit:     "ml/L",
+			From:     Must(Parse(5.0, "%(v/v)")),
+			Expected: 50.0,
+		},
+		testCase{
+			Unit:     "%",
+			From:     Must(Parse(5.0, "% v/v")),
+			Expected: 5.0,
+		},
+		testCase{
+			Unit:     "ppb",
+			From:     Must(Parse(3.0, "ppm")),
+			Expected: 3000.0,
+		},
+		testCase{
+			Unit:       "% w/w",
+			From:       Must(Parse(1.0, "% v/v")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "g/g",
+			From:       Must(Parse(1.0, "% v/v")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "%",
+			From:       Must(Parse(1.0, "% w/v")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+		} else if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestCounts(t *testing.T) {
+	type testCase struct {
+		Unit       string
+		From       Measurement
+		Expected   float64
+		ShouldFail bool
+	}
+
+	suite := []testCase{
+		testCase{
+			Unit:     "bp",
+			From:     Must(Parse(3.0, "kbp")),
+			Expected: 3000.0,
+		},
+		testCase{
+			Unit:     "nt",
+			From:     Must(Parse(20.0, "bp")),
+			Expected: 20.0,
+		},
+		testCase{
+			Unit:     "cells/L",
+			From:     Must(Parse(2.0, "cells/ml")),
+			Expected: 2000.0,
+		},
+		testCase{
+			Unit:     "cfu/ml",
+			From:     Must(Parse(5.0, "CFU/ul")),
+			Expected: 5000.0,
+		},
+		testCase{
+			Unit:     "copies/ul",
+			From:     Must(Parse(1.0, "copy/nl")),
+			Expected: 1000.0,
+		},
+		testCase{
+			Unit:     "nkat",
+			From:     Must(Parse(6.0, "U")),
+			Expected: 100.0,
+		},
+		testCase{
+			Unit:     "U/mg",
+			From:     Must(Parse(1.0, "μkat/g")),
+			Expected: 0.06,
+		},
+		testCase{
+			Unit:     "IU",
+			From:     Must(Parse(2.0, "kU")),
+			Expected: 2000.0,
+		},
+		testCase{
+			Unit:       "ml^-1",
+			From:       Must(Parse(1.0, "cells/ml")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "bp/ml",
+			From:       Must(Parse(1.0, "cells/ml")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "cells",
+			From:       Must(Parse(1.0, "CFU")),
+			ShouldFail: true,
+		},
+		testCase{
+			Unit:       "mol/s",
+			From:       Must(Parse(1.0, "cells/s")),
+			ShouldFail: true,
+		},
+	}
+
+	for _, tc := range suite {
+		m, err := New(tc.Unit, tc.From)
+		if tc.ShouldFail {
+			if err == nil {
+				t.Errorf("expecting error got %v", m)
+			}
+		} else if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.Quantity(); math.Abs(e-f) > 1e-12*e {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestReciprocalUnit(t *testing.T) {
+	type testCase struct {
+		Unit     string
+		Expected string
+	}
+
+	suite := []testCase{
+		testCase{Unit: "m/s", Expected: "s/m"},
+		testCase{Unit: "ml", Expected: "ml^-1"},
+		testCase{Unit: "kg·m/s^2", Expected: "s^2/(kg·m)"},
+		testCase{Unit: "g/g", Expected: "g/g"},
+		testCase{Unit: "", Expected: ""},
+	}
+
+	for _, tc := range suite {
+		m, err := Reciprocal(Must(Parse(2.0, tc.Unit)))
+		if err != nil {
+			t.Error(err)
+		} else if e, f := tc.Expected, m.MeasurementUnit(); e != f {
+			t.Errorf("expecting %q found %q", e, f)
+		} else if _, err := Parse(1.0, f); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestScaleOverflow(t *testing.T) {
 	// MaxFloat ~ 1e308
 	// Yg -> yg ~ 1e48
@@ -75,6 +344,14 @@ func TestScaleOverflow(t *testing.T) {
 	if _, err := New("yg^6", big, rest[:len(rest)-1]...); err != nil {
 		t.Error(err)
 	}
+
+	// Final value is representable even though 10^(48·7) is not
+	small := Must(Parse(1e-100, "Yg"))
+	if m, err := New("yg^7", small, rest...); err != nil {
+		t.Error(err)
+	} else if e, f := 1e236, m.Quantity(); math.Abs(e-f)/e > 1e-15 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
 }
 
 func TestValueOverflow(t *testing.T) {
@@ -88,6 +365,12 @@ func TestValueOverflow(t *testing.T) {
 	if _, err := New("g", Must(Parse(math.MaxFloat64, "g^2")), Must(Reciprocal(Must(Parse(2.0, "g"))))); err != nil {
 		t.Error(err)
 	}
+	// Intermediate product overflows but final value does not
+	if m, err := New("g", Must(Parse(1e200, "g")), Must(Parse(1e200, "g")), Must(Parse(1e-200, "g^-1"))); err != nil {
+		t.Error(err)
+	} else if e, f := 1e200, m.Quantity(); math.Abs(e-f)/e > 1e-15 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
 }
 
 func TestValueUnderflow(t *testing.T) {
@@ -104,4 +387,98 @@ func TestValueUnderflow(t *testing.T) {
 	if _, err := New("dg", Must(Parse(math.SmallestNonzeroFloat64, "g"))); err != nil {
 		t.Error(err)
 	}
+	// Products with an exact zero are zero
+	if m, err := New("s", Must(Parse(0.0, "µl")), Must(Reciprocal(Must(Parse(2.0, "µl/s"))))); err != nil {
+		t.Error(err)
+	} else if e, f := 0.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+	if m, err := New("g", Must(Parse(1e-200, "g^2")), Must(Parse(0.0, "g^-1"))); err != nil {
+		t.Error(err)
+	} else if e, f := 0.0, m.Quantity(); e != f {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+
+	// Intermediate product underflows but final value does not
+	if m, err := New("g", Must(Parse(1e-200, "g")), Must(Parse(1e-200, "g")), Must(Parse(1e200, "g^-1"))); err != nil {
+		t.Error(err)
+	} else if e, f := 1e-200, m.Quantity(); math.Abs(e-f)/e > 1e-15 {
+		t.Errorf("expecting %v found %v", e, f)
+	}
+}
+
+type snapshot struct {
+	Value float64
+	Unit  string
+	unit  pUnit
+}
+
+func takeSnapshot(m Measurement) snapshot {
+	mm := m.(*measure)
+	return snapshot{
+		Value: mm.Value,
+		Unit:  mm.Unit,
+		unit:  *mm.unit,
+	}
+}
+
+func (a snapshot) equal(b snapshot) bool {
+	return a.Value == b.Value && a.Unit == b.Unit &&
+		a.unit.product() == b.unit.product() && a.unit.Scale == b.unit.Scale
+}
+
+func TestImmutable(t *testing.T) {
+	x := Must(Parse(2.0, "m/s"))
+	y := Must(Parse(3.0, "s"))
+	before := []snapshot{takeSnapshot(x), takeSnapshot(y)}
+
+	r := Must(Reciprocal(x))
+	if r == x {
+		t.Error("expecting fresh measurement from Reciprocal")
+	}
+	Must(Reciprocal(r))
+
+	n := Must(New("mm", x, y))
+	if n == x || n == y {
+		t.Error("expecting fresh measurement from New")
+	}
+	Must(New("m/s", x))
+
+	if _, err := New("g", x, y); err == nil {
+		t.Error("expecting error")
+	}
+
+	for idx, m := range []Measurement{x, y} {
+		if e, f := before[idx], takeSnapshot(m); !e.equal(f) {
+			t.Errorf("expecting %v found %v", e, f)
+		}
+	}
+}
+
+func TestConcurrentUse(t *testing.T) {
+	x := Must(Parse(2.0, "ml"))
+	before := takeSnapshot(x)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				r := Must(Reciprocal(x))
+				if e, f := 0.5, r.Quantity(); e != f {
+					t.Errorf("expecting %v found %v", e, f)
+				}
+				m := Must(New("ul", x))
+				if e, f := 2000.0, m.Quantity(); e != f {
+					t.Errorf("expecting %v found %v", e, f)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if f := takeSnapshot(x); !before.equal(f) {
+		t.Errorf("expecting %v found %v", before, f)
+	}
 }